@@ -5,10 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"text/template"
 
 	"github.com/kam9lo/gover/internal/config"
+	"github.com/kam9lo/gover/internal/forge"
+	"github.com/kam9lo/gover/internal/lint"
 	"github.com/kam9lo/gover/internal/prompt"
+	"github.com/kam9lo/gover/internal/report"
 	"github.com/kam9lo/gover/internal/repository"
 	"github.com/kam9lo/gover/internal/version"
 )
@@ -20,68 +24,301 @@ var (
 
 // App is a main application's structure.
 type App struct {
-	cfg  *config.Config
-	repo *repository.Repository
+	cfg       *config.Config
+	repo      *repository.Repository
+	forge     *forge.Forge
+	templates []*messageTemplate
+	linter    *lint.Linter
+	// skipCommands disables commands of arg options sources.
+	skipCommands bool
 }
 
-// NewApp returns new instance of application.
-func NewApp(cfgPath, repoPath string) (*App, error) {
-	cfg, err := config.NewFromFile(cfgPath)
+// NewApp returns new instance of application. Configuration file is
+// discovered from repository path upwards, unless options set its path.
+func NewApp(cfgOpts config.Options, repoPath string) (*App, error) {
+	cfgOpts.Dir = repoPath
+	cfg, err := config.Load(cfgOpts)
 	if err != nil {
-		return nil, fmt.Errorf("new config from file: %w", err)
+		return nil, fmt.Errorf("load config: %w", err)
 	}
 	repo, err := repository.Open(repoPath)
 	if err != nil {
 		return nil, fmt.Errorf("open repository: %w", err)
 	}
+	repo.SetTagPrefix(cfg.Tag.Prefix)
+	templates, err := newMessageTemplates(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("new message templates: %w", err)
+	}
+	linter, err := lint.New(cfg.Lint, cfg.BodyWidth())
+	if err != nil {
+		return nil, fmt.Errorf("new linter: %w", err)
+	}
 	return &App{
-		cfg:  cfg,
-		repo: repo,
+		cfg:          cfg,
+		repo:         repo,
+		forge:        resolveForge(cfg, repo),
+		templates:    templates,
+		linter:       linter,
+		skipCommands: cfgOpts.SkipCommands,
 	}, nil
 }
 
+// resolveForge returns hosting service of the repository with base URL
+// configured or derived from remote URL. Nil is returned when forge is
+// unknown, so templates links are rendered empty.
+func resolveForge(cfg *config.Config, repo *repository.Repository) *forge.Forge {
+	baseURL := cfg.Forge.URL
+	if baseURL == "" {
+		remoteURL, err := repo.RemoteURL(cfg.Forge.Remote)
+		if err != nil {
+			return nil
+		}
+		baseURL, err = forge.ParseRemoteURL(remoteURL)
+		if err != nil {
+			return nil
+		}
+	}
+	f, err := forge.New(forge.Kind(cfg.Forge.Kind), baseURL)
+	if err != nil {
+		return nil
+	}
+	return f
+}
+
+// Destination of commit message created by [App.Commit] and
+// [App.CommitNonInteractive]. Message is printed to stdout, unless message
+// file or commit execution is set.
+type Destination struct {
+	// MsgFile is a path of written message file, e.g. in prepare-commit-msg
+	// hook.
+	MsgFile string
+	// Exec creates commit of staged changes with the message.
+	Exec bool
+	// Commit are options of created commit.
+	Commit repository.CommitOptions
+}
+
 // Commit displays configured in configuration file prompt with values to fill
-// commit message template. If msgFile argument is non-empty, generated text is
-// written directly into the file.
-func (a *App) Commit(msgFile string) (err error) {
-	mp := map[string]string{}
-	for _, arg := range a.cfg.Args {
-		if len(arg.Options) != 0 {
-			mp[arg.Name], err = prompt.Select(arg.Name, arg.Options)
+// commit message template. Rendered message is previewed with violations of
+// lint and verify rules until user confirms it, edits it or aborts. Confirmed
+// message is delivered to the destination.
+func (a *App) Commit(dst Destination) error {
+	if err := a.checkStaged(dst); err != nil {
+		return err
+	}
+	if err := a.resolveOptions(); err != nil {
+		return err
+	}
+
+	known, err := a.knownValues(nil)
+	if err != nil {
+		return err
+	}
+
+	// Args skipped by their conditions are rendered empty.
+	values := map[string]string{}
+	for _, arg := range a.cfg.AllArgs() {
+		values[arg.Name] = ""
+	}
+	answered := map[string]bool{}
+	if err := a.ask(values, answered, known); err != nil {
+		return err
+	}
+	message, err := a.execute(values)
+	if err != nil {
+		return err
+	}
+
+	// Fields can't be edited once the message edited in editor doesn't match
+	// commit template anymore, as their values would overwrite the changes.
+	editable := true
+	for {
+		rep := &report.Report{}
+		c := rep.AddCommit("", "", "")
+		a.verify(c, repository.CleanMessage(message, ""), known)
+		printPreview(message, c.Violations)
+
+		action, err := prompt.Select("Commit message", previewActions(c.Failed(), editable))
+		if err != nil {
+			return err
+		}
+		switch action {
+		case actionConfirm:
+			return a.deliver(message, dst)
+		case actionEdit:
+			name, err := prompt.Select("Field", a.fields(values))
 			if err != nil {
-				return
+				return err
 			}
-		} else {
-			mp[arg.Name], err = prompt.TextInput(arg.Name, arg.Required)
-			if err != nil {
-				return
+			delete(answered, name)
+			if err := a.ask(values, answered, known); err != nil {
+				return err
+			}
+			if message, err = a.execute(values); err != nil {
+				return err
+			}
+		case actionEditor:
+			if message, err = prompt.Editor("commit message", message, nil); err != nil {
+				return err
+			}
+			message += "\n"
+			editable = a.parseValues(message, values)
+		case actionAbort:
+			return ErrAborted
+		}
+	}
+}
+
+// parseValues reads values of args from message edited as a whole. False is
+// returned when the message doesn't match commit template, so values can't
+// be read.
+func (a *App) parseValues(message string, values map[string]string) bool {
+	msg, tmpl, err := a.parse(repository.CleanMessage(message, ""))
+	if err != nil || tmpl.name != config.CommitTemplateName {
+		return false
+	}
+	for name := range values {
+		values[name] = msg[name]
+	}
+	return true
+}
+
+// checkStaged fails early, before prompts, when commit would be executed
+// without staged changes.
+func (a *App) checkStaged(dst Destination) error {
+	if !dst.Exec || dst.Commit.Amend {
+		return nil
+	}
+	staged, err := a.repo.Staged()
+	if err != nil {
+		return err
+	}
+	if !staged {
+		return repository.ErrNothingStaged
+	}
+	return nil
+}
+
+// deliver writes message into the destination.
+func (a *App) deliver(message string, dst Destination) error {
+	switch {
+	case dst.Exec:
+		commit, err := a.repo.Commit(message, dst.Commit)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Printf("[%s] %s\n", commit.Hash[:shortHashLength], commit.Subject())
+		return err
+	case dst.MsgFile != "":
+		return os.WriteFile(dst.MsgFile, []byte(message), 0o644)
+	default:
+		_, err := fmt.Print(message)
+		return err
+	}
+}
+
+const shortHashLength = 7
+
+// ask displays prompts of active args, which are not answered yet. Previous
+// value is the default of text inputs. Values of args skipped by their
+// conditions are cleared, so they are asked again when activated.
+func (a *App) ask(values map[string]string, answered map[string]bool, known map[string][]usedValue) error {
+	active := map[string]bool{}
+	err := a.cfg.Walk(values, func(arg *config.Arg) (err error) {
+		active[arg.Name] = true
+		if answered[arg.Name] {
+			return nil
+		}
+
+		arg = arg.WithValues(values)
+		value := values[arg.Name]
+		used := suggestions(arg, known[arg.Name])
+		switch {
+		case len(arg.Options) != 0 && arg.AllowCustom:
+			value, err = prompt.SelectCustom(arg.Name, arg.Options, arg.Validate)
+		case len(arg.Options) != 0:
+			value, err = prompt.Select(arg.Name, arg.Options)
+		case arg.Multiline:
+			value, err = prompt.Editor(arg.Name, value, arg.Validate)
+		case len(used) != 0:
+			value, err = prompt.SelectCustom(arg.Name, used, arg.Validate)
+			if value == noneItem {
+				value = ""
 			}
+		default:
+			value, err = prompt.TextInputDefault(arg.Name, value, arg.Validate)
+		}
+		if err != nil {
+			return err
+		}
+		values[arg.Name], answered[arg.Name] = arg.Wrap(value), true
+		return nil
+	})
+
+	for name := range values {
+		if !active[name] {
+			values[name] = ""
+			delete(answered, name)
 		}
 	}
+	return err
+}
+
+// fields returns selectable active args with their current values.
+func (a *App) fields(values map[string]string) []prompt.SelectionItem {
+	var items []prompt.SelectionItem
+	_ = a.cfg.Walk(values, func(arg *config.Arg) error {
+		value, _, _ := strings.Cut(values[arg.Name], "\n")
+		if value == "" {
+			value = "empty"
+		}
+		items = append(items, prompt.SelectionItem{Name: arg.Name, Help: value})
+		return nil
+	})
+	return items
+}
+
+// render returns commit message rendered from values and fails when it
+// violates lint rules with error severity.
+func (a *App) render(values map[string]string) (string, error) {
+	message, err := a.execute(values)
+	if err != nil {
+		return "", err
+	}
+
+	findings := a.linter.Lint(lint.Message{
+		Text:   repository.CleanMessage(message, ""),
+		Fields: values,
+	})
+	if err := printFindings(findings); err != nil {
+		return "", err
+	}
+
+	return message, nil
+}
 
+// execute returns commit message rendered from values.
+func (a *App) execute(values map[string]string) (string, error) {
 	tmpl, err := template.New("commit message").Parse(a.cfg.Templates.Commit)
 	if err != nil {
-		return fmt.Errorf("parse template: %w", err)
+		return "", fmt.Errorf("parse template: %w", err)
 	}
 
 	buff := bytes.NewBuffer(nil)
-	if err = tmpl.Execute(buff, mp); err != nil {
-		return fmt.Errorf(
+	if err = tmpl.Execute(buff, values); err != nil {
+		return "", fmt.Errorf(
 			"couldn't execute template\n%s\nwith args:\n%v",
-			a.cfg.Templates.Commit, mp,
+			a.cfg.Templates.Commit, values,
 		)
 	}
-	if msgFile != "" {
-		return os.WriteFile(msgFile, buff.Bytes(), 0o644)
-	}
-
-	return nil
+	return buff.String(), nil
 }
 
 // Next is the next version based on configuration and current branch commit
 // messages.
 func (a *App) Next(pre string) error {
-	change, err := a.change(true)
+	change, err := a.change()
 	if err != nil {
 		return err
 	}
@@ -95,16 +332,219 @@ func (a *App) Next(pre string) error {
 	return nil
 }
 
-// Verify iterates over current branch commits up to latest tagged and
-// validates their message format and template matching.
-func (a *App) Verify() error {
-	_, err := a.change(false)
-	return err
+// Verify iterates over current branch commits up to latest tagged,
+// validates their message format and template matching and prints report in
+// given format. [ErrVerificationFailed] is returned when any commit is
+// invalid.
+func (a *App) Verify(format string) error {
+	if err := a.resolveOptions(); err != nil {
+		return err
+	}
+
+	commits, err := a.commits()
+	if err != nil && !errors.Is(err, repository.ErrCommitNotFound) {
+		return fmt.Errorf("feature commits: %w", err)
+	}
+	return a.verifyCommits(commits, format)
+}
+
+// VerifyRange verifies commits of revision range, e.g. pushed in pre-push
+// hook, like [App.Verify]. See [repository.Repository.Range] for the syntax.
+func (a *App) VerifyRange(rng, format string) error {
+	if err := a.resolveOptions(); err != nil {
+		return err
+	}
+
+	commits, err := a.repo.Range(rng)
+	if err != nil {
+		return fmt.Errorf("commits of %s: %w", rng, err)
+	}
+	return a.verifyCommits(a.withoutIgnored(commits), format)
+}
+
+// verifyCommits prints report of the commits and returns
+// [ErrVerificationFailed] when any of them is invalid.
+func (a *App) verifyCommits(commits []repository.Commit, format string) error {
+	// Values are new if not used before verified commits.
+	verified := map[string]bool{}
+	for _, commit := range commits {
+		verified[commit.Hash] = true
+	}
+	known, err := a.knownValues(verified)
+	if err != nil {
+		return err
+	}
+
+	rep := &report.Report{}
+	for _, commit := range commits {
+		a.verify(rep.AddCommit(commit.Hash, commit.Author, commit.Subject()), commit.Message, known)
+	}
+
+	if err := rep.Write(os.Stdout, format); err != nil {
+		return err
+	}
+	if rep.Failed() {
+		return ErrVerificationFailed
+	}
+	return nil
+}
+
+// VerifyMessage validates single commit message from file, e.g. in
+// commit-msg hook. Git comment lines are ignored. Messages matching ignore
+// rules are not verified, merge commits are recognized by merge in progress.
+func (a *App) VerifyMessage(msgFile, format string) error {
+	if err := a.resolveOptions(); err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(msgFile)
+	if err != nil {
+		return fmt.Errorf("read commit message file: %w", err)
+	}
+	text := repository.CleanMessage(string(content), a.repo.CommentChar())
+
+	merge, err := a.repo.Merging()
+	if err != nil {
+		return err
+	}
+	rep := &report.Report{}
+	// Author of the commit being created isn't known yet.
+	if a.cfg.Ignore.Match(text, "", merge) {
+		return rep.Write(os.Stdout, format)
+	}
+
+	known, err := a.knownValues(nil)
+	if err != nil {
+		return err
+	}
+
+	commit := repository.Commit{Message: text}
+	a.verify(rep.AddCommit("", "", commit.Subject()), text, known)
+
+	if err := rep.Write(os.Stdout, format); err != nil {
+		return err
+	}
+	if rep.Failed() {
+		return ErrVerificationFailed
+	}
+	return nil
+}
+
+// resolveOptions adds options read from sources of args. Sources might run
+// commands, so they are read only by commands validating values. Command
+// sources are skipped when disabled, e.g. by hooks.
+func (a *App) resolveOptions() error {
+	root, err := a.repo.Root()
+	if err != nil {
+		return err
+	}
+	return a.cfg.ResolveOptions(root, !a.skipCommands)
+}
+
+// verify adds violations found in commit message to the report. Values of
+// free-text args are reviewed against known values used in history.
+func (a *App) verify(c *report.Commit, text string, known map[string][]usedValue) {
+	msg, tmpl, err := a.parse(text)
+
+	var merr *repository.MissingFieldError
+	switch {
+	case errors.As(err, &merr):
+		c.Add(
+			report.SeverityError, config.RuleRequired, merr.Field,
+			fmt.Sprintf("%s: value is required by template %q", merr.Field, merr.Template),
+		)
+	case err != nil:
+		c.Add(
+			report.SeverityError, ruleTemplate, "",
+			fmt.Sprintf("%s, commit template: %q", err, a.cfg.Templates.Commit),
+		)
+	default:
+		for _, verr := range a.validate(msg, tmpl) {
+			c.Add(report.SeverityError, verr.Rule, verr.Arg, a.violation(msg, verr))
+		}
+		a.review(c, msg)
+		a.reviewSimilar(c, msg, known)
+	}
+
+	a.lint(c, text, msg)
+}
+
+// violation returns message of validation error with context needed to fix
+// the value: the value itself and, for values not being one of options, the
+// options with their descriptions.
+func (a *App) violation(msg repository.Message, verr *config.ValidationError) string {
+	value := msg[verr.Arg]
+	if value == "" || verr.Rule == config.RuleWhen {
+		return verr.Error()
+	}
+	arg, ok := a.cfg.Arg(verr.Arg)
+	if !ok || verr.Rule != config.RuleEnum {
+		return fmt.Sprintf("%s: value %q %s", verr.Arg, value, verr.Reason)
+	}
+
+	message := fmt.Sprintf(
+		"%s: value %q must be one of: %s",
+		verr.Arg, value, formatOptions(arg.WithValues(msg).Options),
+	)
+	if verr.Suggestion != "" {
+		message += fmt.Sprintf(", did you mean %q?", verr.Suggestion)
+	}
+	return message
+}
+
+// formatOptions returns values of options followed by their descriptions.
+func formatOptions(opts []config.Option) string {
+	parts := make([]string, 0, len(opts))
+	for _, opt := range opts {
+		if opt.Description != "" {
+			parts = append(parts, fmt.Sprintf("%s (%s)", opt.Value, opt.Description))
+		} else {
+			parts = append(parts, opt.Value)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// review adds warnings of custom values entered instead of selecting one of
+// options, so they can be corrected or added to options.
+func (a *App) review(c *report.Commit, msg repository.Message) {
+	_ = a.cfg.Walk(msg, func(arg *config.Arg) error {
+		if value := msg[arg.Name]; arg.WithValues(msg).IsCustom(value) {
+			c.Add(
+				report.SeverityWarning, config.RuleCustom, arg.Name,
+				fmt.Sprintf("%s: custom value %q is not one of options", arg.Name, value),
+			)
+		}
+		return nil
+	})
+}
+
+// lint adds findings of lint rules to the report.
+func (a *App) lint(c *report.Commit, text string, msg repository.Message) {
+	for _, f := range a.linter.Lint(lint.Message{Text: text, Fields: msg}) {
+		c.Add(f.Severity, f.Rule, f.Field, f.Message)
+	}
+}
+
+// printFindings prints lint findings of the commit message to stderr and
+// fails if any of them is an error.
+func printFindings(findings []lint.Finding) error {
+	failed := false
+	for _, f := range findings {
+		fmt.Fprintf(os.Stderr, "%s [%s] %s\n", f.Severity, f.Rule, f.Message)
+		if f.Severity == report.SeverityError {
+			failed = true
+		}
+	}
+	if failed {
+		return errors.New("commit message violates lint rules")
+	}
+	return nil
 }
 
 // Change displays resolved from current branch change type.
 func (a *App) Change() error {
-	change, err := a.change(true)
+	change, err := a.change()
 	if err != nil {
 		return err
 	}
@@ -115,7 +555,7 @@ func (a *App) Change() error {
 }
 
 func (a *App) Commits() error {
-	commits, err := a.repo.FeatureCommits()
+	commits, err := a.commits()
 	if err != nil {
 		return fmt.Errorf("feature commits: %w", err)
 	}
@@ -151,7 +591,9 @@ func (a *App) Changelog() error {
 		}
 	}
 
-	tmpl, err := template.New("changelog").Parse(a.cfg.Templates.Changelog)
+	tmpl, err := template.New("changelog").
+		Funcs(a.templateFuncs()).
+		Parse(a.cfg.Templates.Changelog)
 	if err != nil {
 		return fmt.Errorf("parse template: %w", err)
 	}
@@ -183,7 +625,7 @@ func (a *App) LatestTag() error {
 
 // Tag creates new version tag on last commit.
 func (a *App) Tag(pre string) error {
-	change, err := a.change(true)
+	change, err := a.change()
 	if err != nil {
 		return err
 	}
@@ -197,27 +639,79 @@ func (a *App) Tag(pre string) error {
 	return nil
 }
 
-func (a *App) featureCommits() ([]repository.Message, error) {
+// commits returns commits since last known tag, without ignored ones.
+func (a *App) commits() ([]repository.Commit, error) {
 	commits, err := a.repo.FeatureCommits()
 	if err != nil {
 		return nil, err
 	}
 
+	return a.withoutIgnored(commits), nil
+}
+
+// withoutIgnored returns commits not matching ignore rules.
+func (a *App) withoutIgnored(commits []repository.Commit) []repository.Commit {
+	result := make([]repository.Commit, 0, len(commits))
+	for _, c := range commits {
+		if a.cfg.Ignore.Match(c.Message, c.Signature(), c.Merge) {
+			continue
+		}
+		result = append(result, c)
+	}
+	return result
+}
+
+func (a *App) featureCommits() ([]repository.Message, error) {
+	commits, err := a.commits()
+	if err != nil {
+		return nil, err
+	}
+
 	messages := make([]repository.Message, 0, len(commits))
-	for _, commmit := range commits {
-		msg, err := repository.ParseMessage(
-			a.cfg.Templates.Commit,
-			commmit,
-			a.cfg.RequiredArgs()...,
-		)
+	for _, commit := range commits {
+		msg, _, err := a.parse(commit.Message)
 		if err != nil {
 			continue
 		}
+		msg[repository.FieldHash] = commit.Hash
 		messages = append(messages, msg)
 	}
 	return messages, nil
 }
 
+// templateFuncs returns functions available in changelog template. Links are
+// empty when repository forge is unknown.
+func (a *App) templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"commitURL": func(hash string) string {
+			if a.forge == nil {
+				return ""
+			}
+			return a.forge.CommitURL(hash)
+		},
+		"issueURL": func(id string) string {
+			if a.forge == nil {
+				return ""
+			}
+			return a.forge.IssueURL(id)
+		},
+		"compareURL": func(from, to string) string {
+			if a.forge == nil {
+				return ""
+			}
+			return a.forge.CompareURL(from, to)
+		},
+		"latestTag": a.repo.LatestTag,
+		"nextTag": func() (string, error) {
+			change, err := a.change()
+			if err != nil {
+				return "", err
+			}
+			return a.version(change.String(), "")
+		},
+	}
+}
+
 func (a *App) version(change string, pre string) (string, error) {
 	ct := version.ChangeTypeNone
 	ct.Parse(change)
@@ -234,12 +728,18 @@ func (a *App) version(change string, pre string) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	if a.cfg.Tag.Prefix != "" {
+		tag.Prefix = a.cfg.Tag.Prefix
+	}
+	if pre == "" {
+		pre = a.cfg.Tag.Pre
+	}
 
 	return tag.Next(ct, pre).String(), nil
 }
 
-func (a *App) change(allowMismatch bool) (version.ChangeType, error) {
-	msgs, err := a.repo.FeatureCommits()
+func (a *App) change() (version.ChangeType, error) {
+	msgs, err := a.commits()
 	if err != nil {
 		if errors.Is(err, repository.ErrCommitNotFound) {
 			return version.ChangeTypeNone, nil
@@ -248,22 +748,21 @@ func (a *App) change(allowMismatch bool) (version.ChangeType, error) {
 	}
 
 	versionTypes := map[argName]map[optName]version.ChangeType{}
-	for _, arg := range a.cfg.Args {
+	for _, arg := range a.cfg.AllArgs() {
 		versionTypes[arg.Name] = argOptionsTypes(arg.Options)
 	}
 
 	change := version.ChangeTypeNone
 	for _, msg := range msgs {
-		m, err := repository.ParseMessage(
-			a.cfg.Templates.Commit,
-			msg,
-			a.cfg.RequiredArgs()...,
-		)
-		if err != nil && !allowMismatch {
-			return version.ChangeTypeNone, err
+		m, tmpl, err := a.parse(msg.Message)
+		if err != nil {
+			continue
+		}
+		if tmpl.version > change {
+			change = tmpl.version
 		}
-		for tmpl, val := range m {
-			msgChange := versionTypes[tmpl][val]
+		for field, val := range m {
+			msgChange := versionTypes[field][val]
 			if msgChange > change {
 				change = msgChange
 			}
@@ -273,6 +772,46 @@ func (a *App) change(allowMismatch bool) (version.ChangeType, error) {
 	return change, nil
 }
 
+// validate checks parsed message values against constraints of arguments
+// used in matched template.
+func (a *App) validate(msg repository.Message, tmpl *messageTemplate) []*config.ValidationError {
+	var errs []*config.ValidationError
+	active := map[string]bool{}
+	_ = a.cfg.Walk(msg, func(arg *config.Arg) error {
+		active[arg.Name] = true
+		if tmpl.name != config.CommitTemplateName && !tmpl.uses(arg.Name) {
+			return nil
+		}
+
+		var verr *config.ValidationError
+		if errors.As(arg.WithValues(msg).Validate(msg[arg.Name]), &verr) {
+			errs = append(errs, verr)
+		}
+		return nil
+	})
+
+	// Values of args skipped by their conditions are unexpected in messages
+	// created with commit template.
+	if tmpl.name != config.CommitTemplateName {
+		return errs
+	}
+	for _, arg := range a.cfg.AllArgs() {
+		if active[arg.Name] || msg[arg.Name] == "" {
+			continue
+		}
+		reason := "value is not expected"
+		if len(arg.When) != 0 {
+			reason += " unless " + arg.When.String()
+		}
+		errs = append(errs, &config.ValidationError{
+			Arg:    arg.Name,
+			Rule:   config.RuleWhen,
+			Reason: reason,
+		})
+	}
+	return errs
+}
+
 func argOptionsTypes(opts []config.Option) map[string]version.ChangeType {
 	m := map[string]version.ChangeType{}
 	for _, opt := range opts {
@@ -291,14 +830,12 @@ type (
 	optName = string
 )
 
-const invalidCommitErrMsg = `invalid commit message:
-
-%s
------------------------------------
-template:
-%s
------------------------------------
-options for "%s":
-%s
------------------------------------
-got: "%s"`
+// ruleTemplate is a verification rule of message matching the template.
+const ruleTemplate = "template"
+
+// ErrAborted indicates that user discarded commit message.
+var ErrAborted = errors.New("commit message aborted")
+
+// ErrVerificationFailed indicates that some of verified commit messages are
+// invalid.
+var ErrVerificationFailed = errors.New("verification failed")
@@ -0,0 +1,442 @@
+package internal
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/kam9lo/gover/internal/config"
+	"github.com/kam9lo/gover/internal/prompt"
+	"github.com/kam9lo/gover/internal/report"
+	"github.com/kam9lo/gover/internal/repository"
+)
+
+const testConfig = `
+templates:
+  commit: |
+    {{.Type}}{{if .Scope}}({{.Scope}}){{end}}: {{.Message}}
+    {{- if .Breaking}}
+
+    BREAKING CHANGE: {{.Breaking}}
+    {{- end}}
+    {{- if .Description}}
+
+    {{.Description}}
+    {{- end}}
+args:
+  - name: Type
+    allow_custom: true
+    options:
+      - value: feat!
+        args:
+          - name: Breaking
+            required: true
+      - value: feat
+      - value: fix
+  - name: Scope
+    pattern: ^[a-z]+$
+  - name: Message
+    required: true
+  - name: Description
+    multiline: true
+    width: 20
+`
+
+func TestApp_Commit(t *testing.T) {
+	history := []string{
+		"feat(auth): add login",
+		"fix(auth): handle expired token",
+		"fix(api): validate body",
+	}
+
+	tests := []struct {
+		name    string
+		answers []string
+		want    string
+		wantErr error
+	}{
+		{
+			name:    "text inputs",
+			answers: []string{"fix", "other…", "parser", "handle EOF", "", "confirm"},
+			want:    "fix(parser): handle EOF\n",
+		},
+		{
+			name:    "suggested value",
+			answers: []string{"feat", "auth", "add logout", "", "confirm"},
+			want:    "feat(auth): add logout\n",
+		},
+		{
+			name: "nested arg",
+			answers: []string{
+				"feat!", "drop v1 endpoints", "api", "remove v1", "", "confirm",
+			},
+			want: "feat!(api): remove v1\n\nBREAKING CHANGE: drop v1 endpoints\n",
+		},
+		{
+			name:    "skipped suggested value",
+			answers: []string{"fix", "none", "handle EOF", "", "confirm"},
+			want:    "fix: handle EOF\n",
+		},
+		{
+			name:    "custom option",
+			answers: []string{"other…", "perf", "api", "cache responses", "", "confirm"},
+			want:    "perf(api): cache responses\n",
+		},
+		{
+			name: "wrapped multiline value",
+			answers: []string{
+				"fix", "api", "handle EOF",
+				"reader returned EOF before the last chunk\n\n- retry read\n- log chunk",
+				"confirm",
+			},
+			want: "fix(api): handle EOF\n\nreader returned EOF\nbefore the last\nchunk\n\n- retry read\n- log chunk\n",
+		},
+		{
+			name: "edited field",
+			answers: []string{
+				"feat", "api", "add endpoint", "",
+				"edit", "Type", "feat!", "old endpoint removed",
+				"confirm",
+			},
+			want: "feat!(api): add endpoint\n\nBREAKING CHANGE: old endpoint removed\n",
+		},
+		{
+			name: "edited message",
+			answers: []string{
+				"fix", "api", "handle EOF", "",
+				"editor", "fix(api): handle unexpected EOF",
+				"confirm",
+			},
+			want: "fix(api): handle unexpected EOF\n",
+		},
+		{
+			name: "edited field after edited message",
+			answers: []string{
+				"fix", "api", "handle EOF", "",
+				"editor", "fix(api): handle unexpected EOF",
+				"edit", "Type", "feat",
+				"confirm",
+			},
+			want: "feat(api): handle unexpected EOF\n",
+		},
+		{
+			name: "fields of message not matching template can't be edited",
+			answers: []string{
+				"fix", "api", "handle EOF", "",
+				"editor", "handle EOF",
+				"edit",
+			},
+			wantErr: prompt.ErrInvalidAnswer,
+		},
+		{
+			name:    "aborted",
+			answers: []string{"fix", "api", "handle EOF", "", "abort"},
+			wantErr: ErrAborted,
+		},
+		{
+			name: "invalid message can't be confirmed",
+			answers: []string{
+				"fix", "api", "handle EOF", "",
+				"editor", "handle EOF",
+				"confirm",
+			},
+			wantErr: prompt.ErrInvalidAnswer,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApp(t, testConfig, history...)
+			defer prompt.SetDriver(prompt.SetDriver(prompt.NewScript(tt.answers...)))
+
+			msgFile := filepath.Join(t.TempDir(), "COMMIT_EDITMSG")
+			err := app.Commit(Destination{MsgFile: msgFile})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Commit() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Commit() error = %v", err)
+			}
+
+			got, err := os.ReadFile(msgFile)
+			if err != nil {
+				t.Fatalf("read message file: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Commit() message = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApp_CommitExec(t *testing.T) {
+	t.Setenv("GIT_AUTHOR_NAME", "Jane Doe")
+	t.Setenv("GIT_AUTHOR_EMAIL", "jane@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Jane Doe")
+	t.Setenv("GIT_COMMITTER_EMAIL", "jane@example.com")
+	answers := []string{"fix", "api", "handle EOF", "", "confirm"}
+
+	t.Run("nothing staged", func(t *testing.T) {
+		app := newTestApp(t, testConfig, "feat(api): add endpoint")
+		// Error is returned before prompts, so no answers are needed.
+		defer prompt.SetDriver(prompt.SetDriver(prompt.NewScript()))
+
+		err := app.Commit(Destination{Exec: true})
+		if !errors.Is(err, repository.ErrNothingStaged) {
+			t.Fatalf("Commit() error = %v, want %v", err, repository.ErrNothingStaged)
+		}
+	})
+
+	t.Run("staged changes", func(t *testing.T) {
+		app := newTestApp(t, testConfig, "feat(api): add endpoint")
+		defer prompt.SetDriver(prompt.SetDriver(prompt.NewScript(answers...)))
+
+		root, err := app.repo.Root()
+		if err != nil {
+			t.Fatalf("Root() error = %v", err)
+		}
+		repo, err := git.PlainOpen(root)
+		if err != nil {
+			t.Fatalf("open repository: %v", err)
+		}
+		wt, err := repo.Worktree()
+		if err != nil {
+			t.Fatalf("worktree: %v", err)
+		}
+		if _, err := wt.Add(config.FileName); err != nil {
+			t.Fatalf("add configuration: %v", err)
+		}
+
+		if err := app.Commit(Destination{Exec: true}); err != nil {
+			t.Fatalf("Commit() error = %v", err)
+		}
+		log, err := app.repo.Log(1, nil)
+		if err != nil {
+			t.Fatalf("Log() error = %v", err)
+		}
+		if want := "fix(api): handle EOF"; log[0].Message != want {
+			t.Errorf("HEAD message = %q, want %q", log[0].Message, want)
+		}
+	})
+}
+
+func TestApp_VerifyMessage(t *testing.T) {
+	cfg := testConfig + `ignore:
+  fixups: true
+  merges: true
+`
+	tests := []struct {
+		name    string
+		message string
+		merging bool
+		wantErr error
+	}{
+		{
+			name:    "valid message",
+			message: "fix(api): handle EOF\n# comment\n",
+		},
+		{
+			name:    "invalid message",
+			message: "handle EOF\n",
+			wantErr: ErrVerificationFailed,
+		},
+		{
+			name:    "ignored fixup",
+			message: "fixup! feat: init\n",
+		},
+		{
+			name:    "ignored merge",
+			message: "Merge branch 'x'\n",
+			merging: true,
+		},
+		{
+			name:    "merge message without merge",
+			message: "Merge branch 'x'\n",
+			wantErr: ErrVerificationFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApp(t, cfg, "feat(api): add endpoint")
+			root, err := app.repo.Root()
+			if err != nil {
+				t.Fatalf("Root() error = %v", err)
+			}
+			if tt.merging {
+				head := []byte("0123456789abcdef0123456789abcdef01234567\n")
+				if err := os.WriteFile(filepath.Join(root, ".git", "MERGE_HEAD"), head, 0o644); err != nil {
+					t.Fatalf("write MERGE_HEAD: %v", err)
+				}
+			}
+			msgFile := filepath.Join(t.TempDir(), "COMMIT_EDITMSG")
+			if err := os.WriteFile(msgFile, []byte(tt.message), 0o644); err != nil {
+				t.Fatalf("write message file: %v", err)
+			}
+
+			err = app.VerifyMessage(msgFile, "json")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("VerifyMessage() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApp_VerifyMessage_optionsCommand(t *testing.T) {
+	cfg := `
+templates:
+  commit: "{{.Type}}({{.Scope}}): {{.Message}}"
+args:
+  - name: Type
+    options:
+      - value: fix
+  - name: Scope
+    options:
+      - value: docs
+    options_from:
+      command: echo api
+  - name: Message
+`
+	tests := []struct {
+		name         string
+		message      string
+		skipCommands bool
+		wantErr      error
+	}{
+		{
+			name:    "command option",
+			message: "fix(api): handle EOF",
+		},
+		{
+			name:    "static option",
+			message: "fix(docs): describe hooks",
+		},
+		{
+			name:    "unknown option",
+			message: "fix(cli): handle EOF",
+			wantErr: ErrVerificationFailed,
+		},
+		{
+			name:         "unknown option with skipped commands",
+			message:      "fix(cli): handle EOF",
+			skipCommands: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApp(t, cfg)
+			app.skipCommands = tt.skipCommands
+			msgFile := filepath.Join(t.TempDir(), "COMMIT_EDITMSG")
+			if err := os.WriteFile(msgFile, []byte(tt.message), 0o644); err != nil {
+				t.Fatalf("write message file: %v", err)
+			}
+
+			err := app.VerifyMessage(msgFile, "json")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("VerifyMessage() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApp_verify(t *testing.T) {
+	cfg := `
+templates:
+  commit: "{{.Type}}({{.Scope}}): {{.Message}}"
+args:
+  - name: Type
+    required: true
+    options:
+      - value: feat
+        description: new features
+      - value: fix
+  - name: Scope
+    pattern: ^[a-z]+$
+  - name: Message
+    required: true
+`
+	tests := []struct {
+		message string
+		want    string
+	}{
+		{
+			message: "add parser",
+			want:    `Type: value is required by template "{{.Type}}({{.Scope}}): {{.Message}}"`,
+		},
+		{
+			message: "fix(API): handle EOF",
+			want:    `Scope: value "API" must match pattern ^[a-z]+$`,
+		},
+		{
+			message: "feet(api): add endpoint",
+			want:    `Type: value "feet" must be one of: feat (new features), fix, did you mean "feat"?`,
+		},
+	}
+	app := newTestApp(t, cfg)
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			var c report.Commit
+			app.verify(&c, tt.message, nil)
+			if len(c.Violations) == 0 {
+				t.Fatalf("verify() reported no violations, want %q", tt.want)
+			}
+			if got := c.Violations[0].Message; got != tt.want {
+				t.Errorf("verify() violation = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// newTestApp returns application working on new repository with the
+// configuration and commits of messages, the oldest first.
+func newTestApp(t *testing.T, cfg string, messages ...string) *App {
+	t.Helper()
+	root := newTestRepository(t, messages...)
+	if err := os.WriteFile(filepath.Join(root, config.FileName), []byte(cfg), 0o644); err != nil {
+		t.Fatalf("write configuration: %v", err)
+	}
+
+	app, err := NewApp(config.Options{}, root)
+	if err != nil {
+		t.Fatalf("NewApp() error = %v", err)
+	}
+	return app
+}
+
+// newTestRepository returns root of a new repository with commits of the
+// messages and isolated user configuration.
+func newTestRepository(t *testing.T, messages ...string) string {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	root := t.TempDir()
+	repo, err := git.PlainInit(root, false)
+	if err != nil {
+		t.Fatalf("init repository: %v", err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("worktree: %v", err)
+	}
+	for i, msg := range messages {
+		_, err := wt.Commit(msg, &git.CommitOptions{
+			AllowEmptyCommits: true,
+			Author: &object.Signature{
+				Name:  "Jane Doe",
+				Email: "jane@example.com",
+				When:  time.Date(2024, 1, 1, i, 0, 0, 0, time.UTC),
+			},
+		})
+		if err != nil {
+			t.Fatalf("commit: %v", err)
+		}
+	}
+	return root
+}
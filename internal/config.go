@@ -0,0 +1,63 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/kam9lo/gover/internal/config"
+	"github.com/kam9lo/gover/internal/report"
+)
+
+// ValidateConfig prints semantic problems of configuration file, which is
+// discovered from repository path when cfgPath is empty.
+func ValidateConfig(cfgPath, repoPath string) error {
+	path := cfgPath
+	if path == "" {
+		var err error
+		path, err = config.Discover(repoPath)
+		if err != nil {
+			return fmt.Errorf("discover config: %w", err)
+		}
+	}
+
+	problems, err := config.Check(path)
+	if err != nil {
+		return fmt.Errorf("check config: %w", err)
+	}
+
+	failed := false
+	for _, p := range problems {
+		fmt.Println(p)
+		failed = failed || p.Severity == report.SeverityError
+	}
+	if failed {
+		return ErrVerificationFailed
+	}
+	return nil
+}
+
+// ConfigPath prints path of configuration file, which is discovered from
+// repository path when cfgPath is empty. Hooks run only in repositories with
+// the file.
+func ConfigPath(cfgPath, repoPath string) error {
+	path := cfgPath
+	if path == "" {
+		var err error
+		path, err = config.Discover(repoPath)
+		if err != nil {
+			return fmt.Errorf("discover config: %w", err)
+		}
+	}
+	fmt.Println(path)
+	return nil
+}
+
+// ConfigSchema prints JSON Schema of configuration file.
+func ConfigSchema() error {
+	schema, err := config.Schema()
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(schema)
+	return err
+}
@@ -0,0 +1,566 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template/parse"
+
+	"github.com/go-playground/validator/v10"
+	"gopkg.in/yaml.v3"
+
+	"github.com/kam9lo/gover/internal/fuzzy"
+	"github.com/kam9lo/gover/internal/report"
+)
+
+// Position is a location in configuration file. Line and column are 1-based,
+// zero line means the whole file.
+type Position struct {
+	File   string
+	Line   int
+	Column int
+}
+
+func (p Position) String() string {
+	if p.Line == 0 {
+		return p.File
+	}
+	return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
+}
+
+// Problem is a semantic issue of configuration, which is valid otherwise.
+type Problem struct {
+	Position
+	Severity report.Severity
+	Message  string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s: %s: %s", p.Position, p.Severity, p.Message)
+}
+
+// Check returns problems of configuration file merged over defaults and the
+// user-level file: invalid values and patterns, template syntax errors,
+// template fields without args, args unused in commit template, duplicated
+// option values and conditions on unknown args. Problems are located in the
+// file, in the files and presets it extends or in the user-level file.
+func Check(path string) ([]Problem, error) {
+	files := []string{path}
+	if userFile := UserFile(); userFile != "" {
+		if _, err := os.Stat(userFile); err == nil {
+			files = append(files, userFile)
+		}
+	}
+
+	layer, err := defaults()
+	if err != nil {
+		return nil, err
+	}
+	var sources []source
+	for i := len(files) - 1; i >= 0; i-- {
+		fileLayer, err := readLayer(files[i])
+		if err != nil {
+			return nil, err
+		}
+		layer = merge(layer, fileLayer)
+	}
+	// Sources are searched in order of precedence.
+	for _, file := range files {
+		fileSources, err := readSources(file)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, fileSources...)
+	}
+	cfg, err := decodeLayer(layer)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &checker{cfg: cfg, layer: layer, sources: sources}
+	c.checkValues()
+	c.checkCommitTemplate()
+	for _, t := range cfg.Templates.Parse {
+		c.checkParseTemplate(t)
+	}
+	if cfg.Templates.Changelog != "" {
+		c.parseTemplate(cfg.Templates.Changelog, templatesKey, "changelog")
+	}
+	c.checkOptions()
+	c.checkConditions()
+
+	return c.problems, nil
+}
+
+type checker struct {
+	cfg *Config
+	// layer is the merged configuration cfg is decoded from.
+	layer    map[string]any
+	sources  []source
+	problems []Problem
+}
+
+// checkValues reports values violating constraints of configuration
+// structure and invalid regular expressions, which fail loading of the
+// configuration.
+func (c *checker) checkValues() {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		return name
+	})
+	var verrs validator.ValidationErrors
+	if errors.As(v.Struct(c.cfg), &verrs) {
+		for _, fe := range verrs {
+			c.add(c.position(c.namespaceKeys(fe.Namespace())...), report.SeverityError, "%s", describe(fe))
+		}
+	}
+
+	for _, arg := range c.cfg.AllArgs() {
+		keys := []string{argsKey, nameKey + "=" + arg.Name}
+		if arg.Pattern != "" {
+			if _, err := arg.compile(); err != nil {
+				c.add(c.position(append(keys, "pattern")...), report.SeverityError, "%s", err)
+			}
+		}
+		if arg.OptionsFrom != nil {
+			if err := arg.OptionsFrom.validate(); err != nil {
+				c.add(c.position(append(keys, "options_from")...), report.SeverityError, "arg %s: %s", arg.Name, err)
+			}
+		}
+	}
+
+	ignored := map[string][]string{"messages": c.cfg.Ignore.Messages, "authors": c.cfg.Ignore.Authors}
+	for _, key := range []string{"messages", "authors"} {
+		for i, pattern := range ignored[key] {
+			if _, err := regexp.Compile(pattern); err != nil {
+				c.add(
+					c.position(ignoreKey, key, strconv.Itoa(i)), report.SeverityError,
+					"ignore %s: invalid pattern: %s", key, err,
+				)
+			}
+		}
+	}
+}
+
+// namespaceKeys returns keys path of validated field namespace, e.g.
+// "Config.args[1].minLength". Items of named lists, like args, are
+// referred by name, as their indexes differ between merged layers.
+func (c *checker) namespaceKeys(namespace string) []string {
+	_, namespace, _ = strings.Cut(namespace, ".")
+
+	var (
+		keys []string
+		node any = c.layer
+	)
+	for _, part := range strings.Split(namespace, ".") {
+		name, index, indexed := strings.Cut(part, "[")
+		keys = append(keys, name)
+		parent, _ := node.(map[string]any)
+		node = parent[name]
+		if !indexed {
+			continue
+		}
+
+		key := strings.TrimSuffix(index, "]")
+		switch typed := node.(type) {
+		case map[string]any:
+			node = typed[key]
+		case []any:
+			i, err := strconv.Atoi(key)
+			if err != nil || i >= len(typed) {
+				return keys
+			}
+			node = typed[i]
+			if item, ok := node.(map[string]any); ok {
+				if n, ok := item[nameKey].(string); ok {
+					key = nameKey + "=" + n
+				}
+			}
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// describe returns message of field validation error.
+func describe(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return fmt.Sprintf("%s is required", fe.Field())
+	case "oneof":
+		return fmt.Sprintf(
+			"%s %v must be one of: %s",
+			fe.Field(), fe.Value(), strings.ReplaceAll(fe.Param(), " ", ", "),
+		)
+	case "gt":
+		if fe.Kind() == reflect.Slice {
+			return fmt.Sprintf("%s must not be empty", fe.Field())
+		}
+		return fmt.Sprintf("%s must be greater than %s", fe.Field(), fe.Param())
+	case "gte":
+		return fmt.Sprintf("%s must be at least %s", fe.Field(), fe.Param())
+	case "url":
+		return fmt.Sprintf("%s %v must be a URL", fe.Field(), fe.Value())
+	default:
+		return fmt.Sprintf("%s fails %s validation", fe.Field(), fe.Tag())
+	}
+}
+
+func (c *checker) checkCommitTemplate() {
+	keys := []string{templatesKey, "commit"}
+	fields, ok := c.parseTemplate(c.cfg.Templates.Commit, keys...)
+	if !ok {
+		return
+	}
+	c.checkFields(fields, true, keys...)
+
+	used := map[string]bool{}
+	for _, f := range fields {
+		used[f.name] = true
+	}
+	for _, arg := range c.cfg.AllArgs() {
+		if !used[arg.Name] {
+			c.add(
+				c.position(argsKey, nameKey+"="+arg.Name), report.SeverityWarning,
+				"arg %s is not used in commit template", arg.Name,
+			)
+		}
+	}
+}
+
+func (c *checker) checkParseTemplate(t ParseTemplate) {
+	keys := []string{templatesKey, "parse", nameKey + "=" + t.Name, "template"}
+	if fields, ok := c.parseTemplate(t.Template, keys...); ok {
+		c.checkFields(fields, false, keys...)
+	}
+}
+
+// checkFields reports template fields, which are not defined in args. Parse
+// templates may read additional fields, so with strict disabled only fields
+// similar to args are reported as likely typos.
+func (c *checker) checkFields(fields []templateField, strict bool, keys ...string) {
+	names := c.argNames()
+	for _, f := range fields {
+		if _, ok := c.cfg.Arg(f.name); ok {
+			continue
+		}
+		pos := c.templatePosition(f.line, f.column, keys...)
+		msg := fmt.Sprintf("field %s is not defined in args", f.name)
+		suggestion, similar := fuzzy.Closest(f.name, names, maxSuggestionDistance)
+		if similar {
+			msg += fmt.Sprintf(", did you mean %s?", suggestion)
+		}
+		switch {
+		case strict:
+			c.add(pos, report.SeverityError, "%s", msg)
+		case similar:
+			c.add(pos, report.SeverityWarning, "%s", msg)
+		}
+	}
+}
+
+func (c *checker) argNames() []string {
+	names := []string{}
+	for _, arg := range c.cfg.AllArgs() {
+		names = append(names, arg.Name)
+	}
+	return names
+}
+
+// checkConditions reports conditions of args and options referring to
+// unknown args.
+func (c *checker) checkConditions() {
+	check := func(when Condition, keys ...string) {
+		for name := range when {
+			if _, ok := c.cfg.Arg(name); !ok {
+				c.add(
+					c.position(append(keys, whenKey, name)...), report.SeverityError,
+					"condition refers to unknown arg %s", name,
+				)
+			}
+		}
+	}
+	for _, arg := range c.cfg.AllArgs() {
+		keys := []string{argsKey, nameKey + "=" + arg.Name}
+		check(arg.When, keys...)
+		for i, o := range arg.Options {
+			check(o.When, append(keys, optionsKey, strconv.Itoa(i))...)
+		}
+	}
+}
+
+// checkOptions reports options with value already used by other option of
+// the same arg.
+func (c *checker) checkOptions() {
+	for _, arg := range c.cfg.AllArgs() {
+		seen := map[string]bool{}
+		for i, o := range arg.Options {
+			if seen[o.Value] {
+				c.add(
+					c.position(argsKey, nameKey+"="+arg.Name, optionsKey, strconv.Itoa(i)),
+					report.SeverityError,
+					"duplicated option %q of arg %s", o.Value, arg.Name,
+				)
+			}
+			seen[o.Value] = true
+		}
+	}
+}
+
+// parseTemplate reports syntax error of template and returns fields it reads.
+// Functions are not checked, as they are provided by the application.
+func (c *checker) parseTemplate(text string, keys ...string) ([]templateField, bool) {
+	tree := parse.New(keys[len(keys)-1])
+	tree.Mode = parse.SkipFuncCheck
+	if _, err := tree.Parse(text, "", "", map[string]*parse.Tree{}); err != nil {
+		line, msg := 0, err.Error()
+		if m := templateErrRgx.FindStringSubmatch(msg); m != nil {
+			line, _ = strconv.Atoi(m[1])
+			msg = m[2]
+		}
+		c.add(c.templatePosition(line, 1, keys...), report.SeverityError, "template syntax: %s", msg)
+		return nil, false
+	}
+	if tree.Root == nil {
+		return nil, true
+	}
+
+	var fields []templateField
+	walkFields(tree.Root, func(n *parse.FieldNode) {
+		location, _ := tree.ErrorContext(n)
+		parts := strings.Split(location, ":")
+		line, _ := strconv.Atoi(parts[len(parts)-2])
+		offset, _ := strconv.Atoi(parts[len(parts)-1])
+		fields = append(fields, templateField{name: n.Ident[0], line: line, column: offset + 1})
+	})
+	return fields, true
+}
+
+var templateErrRgx = regexp.MustCompile(`^template: [^:]*:(\d+):\s*(.*)$`)
+
+func (c *checker) add(pos Position, severity report.Severity, format string, args ...any) {
+	c.problems = append(c.problems, Problem{
+		Position: pos,
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// position returns location of the node at keys path in the first source
+// defining it. Parent node is located when the node is not found.
+func (c *checker) position(keys ...string) Position {
+	for n := len(keys); n > 0; n-- {
+		for _, src := range c.sources {
+			if node := lookup(src.root, keys[:n]...); node != nil {
+				return Position{File: src.name, Line: node.Line, Column: node.Column}
+			}
+		}
+	}
+	return Position{File: c.sources[0].name}
+}
+
+// templatePosition returns location in configuration file of the template
+// line and column.
+func (c *checker) templatePosition(line, column int, keys ...string) Position {
+	for _, src := range c.sources {
+		node := lookup(src.root, keys...)
+		if node == nil {
+			continue
+		}
+		if line == 0 {
+			return Position{File: src.name, Line: node.Line, Column: node.Column}
+		}
+		if node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+			// Block scalar content starts in the line after indicator.
+			pos := Position{File: src.name, Line: node.Line + line, Column: column}
+			if pos.Line <= len(src.lines) {
+				content := src.lines[pos.Line-1]
+				pos.Column += len(content) - len(strings.TrimLeft(content, " "))
+			}
+			return pos
+		}
+		if line == 1 {
+			column += node.Column - 1
+			if node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0 {
+				column++
+			}
+		}
+		return Position{File: src.name, Line: node.Line + line - 1, Column: column}
+	}
+	return c.position(keys...)
+}
+
+type templateField struct {
+	name   string
+	line   int
+	column int
+}
+
+// walkFields calls fn for fields of template data. Fields inside "range" and
+// "with" bodies are skipped, as they read other data.
+func walkFields(node parse.Node, fn func(*parse.FieldNode)) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			walkFields(child, fn)
+		}
+	case *parse.ActionNode:
+		walkFields(n.Pipe, fn)
+	case *parse.IfNode:
+		walkFields(n.Pipe, fn)
+		walkFields(n.List, fn)
+		walkFields(n.ElseList, fn)
+	case *parse.RangeNode:
+		walkFields(n.Pipe, fn)
+		walkFields(n.ElseList, fn)
+	case *parse.WithNode:
+		walkFields(n.Pipe, fn)
+		walkFields(n.ElseList, fn)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			walkFields(cmd, fn)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			walkFields(arg, fn)
+		}
+	case *parse.ChainNode:
+		walkFields(n.Node, fn)
+	case *parse.FieldNode:
+		fn(n)
+	}
+}
+
+// source is a configuration file, or preset, decoded into YAML nodes.
+type source struct {
+	name  string
+	root  *yaml.Node
+	lines []string
+}
+
+func newSource(name string, content []byte) (source, error) {
+	root := &yaml.Node{}
+	if err := yaml.Unmarshal(content, root); err != nil {
+		return source{}, fmt.Errorf("decode %s: %w", name, err)
+	}
+	return source{
+		name:  name,
+		root:  root,
+		lines: strings.Split(string(content), "\n"),
+	}, nil
+}
+
+// readSources returns the file followed by configurations it extends.
+func readSources(path string) ([]source, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read file: %w", err)
+	}
+	if filepath.Ext(path) == ".toml" {
+		// Positions in TOML files are not known, problems refer to the file.
+		return []source{{name: path, root: &yaml.Node{}}}, nil
+	}
+	src, err := newSource(path, content)
+	if err != nil {
+		return nil, err
+	}
+	base, err := extendedSources(src, filepath.Dir(path))
+	if err != nil {
+		return nil, err
+	}
+	return append([]source{src}, base...), nil
+}
+
+func extendedSources(src source, dir string) ([]source, error) {
+	node := lookup(src.root, extendsKey)
+	if node == nil || node.Value == "" {
+		return nil, nil
+	}
+	if !isPresetName(node.Value) {
+		path := node.Value
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		return readSources(path)
+	}
+
+	name := presetsDir + "/" + node.Value + presetExt
+	content, err := presets.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("read preset: %w", err)
+	}
+	preset, err := newSource(name, content)
+	if err != nil {
+		return nil, err
+	}
+	base, err := extendedSources(preset, "")
+	if err != nil {
+		return nil, err
+	}
+	return append([]source{preset}, base...), nil
+}
+
+// lookup returns node at keys path. Mapping nodes are indexed by key and
+// sequence nodes by index or "key=value" of the item.
+func lookup(node *yaml.Node, keys ...string) *yaml.Node {
+	if node.Kind == yaml.DocumentNode {
+		if len(node.Content) == 0 {
+			return nil
+		}
+		node = node.Content[0]
+	}
+	for _, key := range keys {
+		if node = child(node, key); node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+func child(node *yaml.Node, key string) *yaml.Node {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				return node.Content[i+1]
+			}
+		}
+	case yaml.SequenceNode:
+		if i, err := strconv.Atoi(key); err == nil {
+			if i >= 0 && i < len(node.Content) {
+				return node.Content[i]
+			}
+			return nil
+		}
+		field, value, _ := strings.Cut(key, "=")
+		for _, item := range node.Content {
+			if item.Kind != yaml.MappingNode {
+				continue
+			}
+			if v := child(item, field); v != nil && v.Value == value {
+				return item
+			}
+		}
+	}
+	return nil
+}
+
+const (
+	templatesKey = "templates"
+	argsKey      = "args"
+	optionsKey   = "options"
+	whenKey      = "when"
+	ignoreKey    = "ignore"
+)
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	root := t.TempDir()
+	path := filepath.Join(root, "gover.yml")
+	writeFile(t, path, `templates:
+  commit: |
+    {{.Type}}: {{.Mesage}}
+  changelog: "{{if .Type}}"
+  parse:
+    - name: legacy
+      template: "[{{.Tpe}}] {{.Message}} {{.PR}}"
+args:
+  - name: Type
+    options:
+      - value: feat
+      - value: fix
+      - value: feat
+  - name: Message
+`)
+
+	problems, err := Check(path)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	got := make([]string, 0, len(problems))
+	for _, p := range problems {
+		got = append(got, p.String())
+	}
+	want := []string{
+		path + ":3:18: error: field Mesage is not defined in args, did you mean Message?",
+		path + ":14:5: warning: arg Message is not used in commit template",
+		path + ":7:21: warning: field Tpe is not defined in args, did you mean Type?",
+		path + ":4:15: error: template syntax: unexpected EOF",
+		path + ":13:9: error: duplicated option \"feat\" of arg Type",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Check() =\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestCheck_Preset(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	root := t.TempDir()
+	path := filepath.Join(root, "gover.yml")
+	writeFile(t, path, `extends: conventional
+args:
+  - name: Ticket
+    when:
+      Tpye: [feat]
+`)
+
+	problems, err := Check(path)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if len(problems) != 2 || problems[0].Line != 3 || problems[1].Line != 5 {
+		t.Errorf("Check() = %v, want unused Ticket arg in line 3 and unknown condition arg in line 5", problems)
+	}
+}
+
+func TestCheck_Values(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	userFile := UserFile()
+	writeFile(t, userFile, `args:
+  - name: Team
+    minLength: -1
+`)
+	root := t.TempDir()
+	path := filepath.Join(root, "gover.yml")
+	writeFile(t, path, `templates:
+  commit: "{{.Tpye}}: {{.Message}} {{.Team}}"
+  parse:
+    - name: legacy
+      template: "{{.Message}}"
+      version: mjor
+args:
+  - name: Type
+    pattern: '([a-z'
+  - name: Message
+lint:
+  subject-max-length:
+    severity: fatal
+ignore:
+  messages: ["^WIP", "(["]
+`)
+
+	problems, err := Check(path)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	got := make([]string, 0, len(problems))
+	for _, p := range problems {
+		got = append(got, p.String())
+	}
+	want := []string{
+		path + ":6:16: error: version mjor must be one of: major, minor, patch",
+		userFile + ":3:16: error: minLength must be at least 0",
+		path + ":13:15: error: severity fatal must be one of: error, warning, off",
+		path + ":9:14: error: arg Type: invalid pattern: error parsing regexp: missing closing ]: `[a-z`",
+		path + ":15:22: error: ignore messages: invalid pattern: error parsing regexp: missing closing ]: `[`",
+		path + ":2:14: error: field Tpye is not defined in args, did you mean Type?",
+		path + ":8:5: warning: arg Type is not used in commit template",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Check() =\n%v\nwant\n%v", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
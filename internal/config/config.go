@@ -2,16 +2,26 @@ package config
 
 import (
 	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/go-playground/validator/v10"
+
+	"github.com/kam9lo/gover/internal/fuzzy"
+	"github.com/kam9lo/gover/internal/textwrap"
 )
 
 // Option is an option for selection prompt where value fills provided in
 // template field and version specifies its impact on semver version.
 type Option struct {
-	Value       string `json:"value" yaml:"value" validate:"required"`
-	Description string `json:"description" yaml:"description" validate:""`
-	Version     string `json:"version" yaml:"version" validate:"oneof=major minor patch"`
+	Value       string    `json:"value" yaml:"value" validate:"required" doc:"value inserted into commit message"`
+	Description string    `json:"description" yaml:"description" validate:"" doc:"description displayed next to the value in prompt"`
+	Version     string    `json:"version" yaml:"version" validate:"oneof=major minor patch" doc:"version change caused by commits with this value"`
+	When        Condition `json:"when,omitempty" yaml:"when,omitempty" validate:"-" doc:"option is offered only when values of other args match"`
+	Args        []Arg     `json:"args,omitempty" yaml:"args,omitempty" validate:"-" doc:"args asked after selecting this option"`
 }
 
 // Field implements selectable option field.
@@ -24,38 +34,476 @@ func (o Option) Doc() string {
 	return o.Description
 }
 
+// Arg is a specification of commit message template field and constraints
+// of its value.
+type Arg struct {
+	Name            string         `json:"name,omitempty" yaml:"name" validate:"required" doc:"name of commit message template field"`
+	Options         []Option       `json:"options,omitempty" yaml:"options" validate:"-" doc:"values selectable in prompt"`
+	Required        bool           `json:"required,omitempty" yaml:"required,omitempty" validate:"-" doc:"value must not be empty"`
+	Width           int            `json:"width,omitempty" yaml:"width" validate:"omitempty,gte=0" doc:"wrap width of the value in columns, 72 by default, the widest one is the body line length limit"`
+	Pattern         string         `json:"pattern,omitempty" yaml:"pattern,omitempty" validate:"-" doc:"regular expression the value must match"`
+	MinLength       int            `json:"minLength,omitempty" yaml:"minLength,omitempty" validate:"omitempty,gte=0" doc:"minimum value length in characters"`
+	MaxLength       int            `json:"maxLength,omitempty" yaml:"maxLength,omitempty" validate:"omitempty,gte=0" doc:"maximum value length in characters"`
+	EnumFromOptions *bool          `json:"enum-from-options,omitempty" yaml:"enum-from-options,omitempty" validate:"-" doc:"value must be one of options, enabled by default when options are set"`
+	When            Condition      `json:"when,omitempty" yaml:"when,omitempty" validate:"-" doc:"arg is asked only when values of other args match"`
+	OptionsFrom     *OptionsSource `json:"options_from,omitempty" yaml:"options_from,omitempty" validate:"-" doc:"source of options added to static ones at runtime"`
+	Multiline       bool           `json:"multiline,omitempty" yaml:"multiline,omitempty" validate:"-" doc:"value is edited in $VISUAL or $EDITOR and may contain paragraphs and lists"`
+	AllowCustom     bool           `json:"allow_custom,omitempty" yaml:"allow_custom,omitempty" validate:"-" doc:"prompt offers other entry for values not listed in options, verify reports them as warnings"`
+
+	pattern *regexp.Regexp
+}
+
+// Condition maps arg names to values, one of which the arg must have, e.g.
+// "Type: [feat, fix]". Empty condition is always met.
+type Condition map[string][]string
+
+// Match returns true if all args have one of the condition values.
+func (c Condition) Match(values map[string]string) bool {
+	for name, accepted := range c {
+		if !slices.Contains(accepted, values[name]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c Condition) String() string {
+	names := make([]string, 0, len(c))
+	for name := range c {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("%s is one of: %s", name, strings.Join(c[name], ", ")))
+	}
+	return strings.Join(parts, " and ")
+}
+
+// Validate checks value against constraints of the argument. Returned error
+// is a [*ValidationError].
+func (a *Arg) Validate(value string) error {
+	if value == "" {
+		if a.Required {
+			return a.errorf(RuleRequired, "value is required")
+		}
+		return nil
+	}
+
+	length := utf8.RuneCountInString(value)
+	if a.MinLength > 0 && length < a.MinLength {
+		return a.errorf(RuleMinLength, "must have at least %d characters, got %d", a.MinLength, length)
+	}
+	if a.MaxLength > 0 && length > a.MaxLength {
+		return a.errorf(RuleMaxLength, "must have at most %d characters, got %d", a.MaxLength, length)
+	}
+	if a.Pattern != "" {
+		rgx, err := a.compile()
+		if err != nil {
+			return err
+		}
+		if !rgx.MatchString(value) {
+			return a.errorf(RulePattern, "must match pattern %s", a.Pattern)
+		}
+	}
+	if a.IsEnum() && !a.HasOption(value) {
+		if a.AllowCustom {
+			return nil
+		}
+		err := a.errorf(RuleEnum, "must be one of: %s", strings.Join(a.OptionValues(), ", "))
+		if closest, ok := fuzzy.Closest(value, a.OptionValues(), maxSuggestionDistance); ok {
+			err.Suggestion = closest
+			err.Reason += fmt.Sprintf(", did you mean %q?", closest)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// IsEnum returns true if value has to be one of the options. Selectable
+// arguments are enums unless disabled with enum-from-options. Arguments with
+// unresolved options source aren't enums, as their options aren't known.
+func (a *Arg) IsEnum() bool {
+	if len(a.Options) == 0 || a.OptionsFrom != nil {
+		return false
+	}
+	return a.EnumFromOptions == nil || *a.EnumFromOptions
+}
+
+// Wrap returns value with paragraphs and list items wrapped at the arg width,
+// [DefaultWidth] unless configured.
+func (a *Arg) Wrap(value string) string {
+	width := a.Width
+	if width == 0 {
+		width = DefaultWidth
+	}
+	return textwrap.Wrap(value, width)
+}
+
+// DefaultWidth is a wrap width of values in columns.
+const DefaultWidth = 72
+
+// IsCustom returns true if value was entered instead of selected from options
+// of enum argument allowing custom values. Such values are valid, but might
+// need review, e.g. to be added to options.
+func (a *Arg) IsCustom(value string) bool {
+	return a.AllowCustom && a.IsEnum() && value != "" && !a.HasOption(value)
+}
+
+// HasOption returns true if value is one of the argument's options.
+func (a *Arg) HasOption(value string) bool {
+	for _, opt := range a.Options {
+		if opt.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Option returns argument's option with given value.
+func (a *Arg) Option(value string) (*Option, bool) {
+	for i := range a.Options {
+		if a.Options[i].Value == value {
+			return &a.Options[i], true
+		}
+	}
+	return nil, false
+}
+
+// WithValues returns copy of the argument with options, which conditions are
+// met by values of other args.
+func (a *Arg) WithValues(values map[string]string) *Arg {
+	arg := *a
+	arg.Options = nil
+	for _, o := range a.Options {
+		if o.When.Match(values) {
+			arg.Options = append(arg.Options, o)
+		}
+	}
+	return &arg
+}
+
+// OptionValues returns values of the argument's options.
+func (a *Arg) OptionValues() []string {
+	values := make([]string, 0, len(a.Options))
+	for _, opt := range a.Options {
+		values = append(values, opt.Value)
+	}
+	return values
+}
+
+func (a *Arg) compile() (*regexp.Regexp, error) {
+	if a.pattern != nil {
+		return a.pattern, nil
+	}
+	rgx, err := regexp.Compile(a.Pattern)
+	if err != nil {
+		return nil, fmt.Errorf("arg %s: invalid pattern: %w", a.Name, err)
+	}
+	a.pattern = rgx
+	return rgx, nil
+}
+
+func (a *Arg) errorf(rule, format string, args ...any) *ValidationError {
+	return &ValidationError{
+		Arg:    a.Name,
+		Rule:   rule,
+		Reason: fmt.Sprintf(format, args...),
+	}
+}
+
+// Argument value validation rules.
+const (
+	RuleRequired  = "required"
+	RuleMinLength = "minLength"
+	RuleMaxLength = "maxLength"
+	RulePattern   = "pattern"
+	RuleEnum      = "enum-from-options"
+	RuleWhen      = "when"
+	RuleCustom    = "allow_custom"
+)
+
+// ValidationError describes argument value that breaks one of the rules.
+type ValidationError struct {
+	Arg    string
+	Rule   string
+	Reason string
+	// Suggestion is the closest valid value, if any.
+	Suggestion string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Arg, e.Reason)
+}
+
+// ParseTemplate is an additional template of accepted commit messages, e.g.
+// legacy format or merge commits. Templates are tried in order of descending
+// priority, starting with the commit template with priority 0.
+type ParseTemplate struct {
+	Name     string `json:"name" yaml:"name" validate:"required" doc:"template name exposed as Template field of parsed message"`
+	Template string `json:"template" yaml:"template" validate:"required" doc:"template of accepted commit message"`
+	Priority int    `json:"priority,omitempty" yaml:"priority,omitempty" validate:"-" doc:"templates with higher priority are tried first, commit template has 0"`
+	Version  string `json:"version,omitempty" yaml:"version,omitempty" validate:"omitempty,oneof=major minor patch" doc:"version change caused by matching commits"`
+}
+
+// CommitTemplateName is the name of the commit template among parse
+// templates.
+const CommitTemplateName = "commit"
+
+// ParseTemplates returns commit template followed by additional parse
+// templates, sorted by priority.
+func (c *Config) ParseTemplates() []ParseTemplate {
+	templates := append([]ParseTemplate{{
+		Name:     CommitTemplateName,
+		Template: c.Templates.Commit,
+	}}, c.Templates.Parse...)
+
+	sort.SliceStable(templates, func(i, j int) bool {
+		return templates[i].Priority > templates[j].Priority
+	})
+	return templates
+}
+
+// LintRule is a configuration of commit message lint rule identified by map
+// key. Options apply only to rules that use them.
+type LintRule struct {
+	Severity string   `json:"severity,omitempty" yaml:"severity,omitempty" validate:"omitempty,oneof=error warning off" doc:"errors fail verification, off disables the rule"`
+	Max      int      `json:"max,omitempty" yaml:"max,omitempty" validate:"omitempty,gt=0" doc:"maximum length of length rules"`
+	Words    []string `json:"words,omitempty" yaml:"words,omitempty" validate:"-" doc:"words of forbidden-words rule"`
+	Field    string   `json:"field,omitempty" yaml:"field,omitempty" validate:"-" doc:"field checked by type-lowercase rule"`
+}
+
+// Arg returns argument with given name, including args nested in options.
+func (c *Config) Arg(name string) (*Arg, bool) {
+	for _, arg := range c.AllArgs() {
+		if arg.Name == name {
+			return arg, true
+		}
+	}
+	return nil, false
+}
+
+// AllArgs returns args followed by args nested in their options. Only the
+// first arg of each name is returned.
+func (c *Config) AllArgs() []*Arg {
+	var all []*Arg
+	seen := map[string]bool{}
+	var collect func(args []Arg)
+	collect = func(args []Arg) {
+		for i := range args {
+			if !seen[args[i].Name] {
+				seen[args[i].Name] = true
+				all = append(all, &args[i])
+			}
+			for j := range args[i].Options {
+				collect(args[i].Options[j].Args)
+			}
+		}
+	}
+	collect(c.Args)
+	return all
+}
+
+// Walk calls fn for args, which conditions are met by values, in prompt
+// order: each arg is followed by args nested in its selected option. Values
+// are read after each call, so fn may fill them.
+func (c *Config) Walk(values map[string]string, fn func(*Arg) error) error {
+	return walkArgs(c.Args, values, fn)
+}
+
+func walkArgs(args []Arg, values map[string]string, fn func(*Arg) error) error {
+	for i := range args {
+		arg := &args[i]
+		if !arg.When.Match(values) {
+			continue
+		}
+		if err := fn(arg); err != nil {
+			return err
+		}
+		if o, ok := arg.Option(values[arg.Name]); ok {
+			if err := walkArgs(o.Args, values, fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ResolveOptions adds options read from sources of args. Sources are read
+// in repository root directory. Command sources are run only if commands is
+// true, otherwise their args stay unresolved and values aren't checked
+// against options.
+func (c *Config) ResolveOptions(root string, commands bool) error {
+	for _, arg := range c.AllArgs() {
+		if arg.OptionsFrom == nil || arg.OptionsFrom.Command != "" && !commands {
+			continue
+		}
+		values, err := arg.OptionsFrom.Values(root)
+		if err != nil {
+			return fmt.Errorf("arg %s options: %w", arg.Name, err)
+		}
+		for _, v := range values {
+			if !arg.HasOption(v) {
+				arg.Options = append(arg.Options, Option{Value: v})
+			}
+		}
+		arg.OptionsFrom = nil
+	}
+	return nil
+}
+
+// BodyWidth returns the widest configured arguments width, or zero if none
+// is configured.
+func (c *Config) BodyWidth() (width int) {
+	for _, arg := range c.AllArgs() {
+		width = max(width, arg.Width)
+	}
+	return
+}
+
+// Ignore specifies commits skipped by verification, changelog and version
+// change detection.
+type Ignore struct {
+	// Messages are regular expressions matching ignored commit messages.
+	Messages []string `json:"messages,omitempty" yaml:"messages,omitempty" validate:"-" doc:"regular expressions matching ignored commit messages"`
+	// Authors are regular expressions matching ignored authors in
+	// "Name <email>" format.
+	Authors []string `json:"authors,omitempty" yaml:"authors,omitempty" validate:"-" doc:"regular expressions matching ignored authors in Name <email> format"`
+	// Merges ignores commits with more than one parent.
+	Merges bool `json:"merges,omitempty" yaml:"merges,omitempty" validate:"-" doc:"ignore commits with more than one parent"`
+	// Fixups ignores "fixup!", "squash!" and "amend!" commits.
+	Fixups bool `json:"fixups,omitempty" yaml:"fixups,omitempty" validate:"-" doc:"ignore fixup!, squash! and amend! commits"`
+
+	messages []*regexp.Regexp
+	authors  []*regexp.Regexp
+}
+
+// Match returns true if commit with given message, author and merge flag is
+// ignored.
+func (i *Ignore) Match(message, author string, merge bool) bool {
+	if i.Merges && merge {
+		return true
+	}
+	if i.Fixups {
+		for _, prefix := range fixupPrefixes {
+			if strings.HasPrefix(message, prefix) {
+				return true
+			}
+		}
+	}
+	for _, rgx := range i.messages {
+		if rgx.MatchString(message) {
+			return true
+		}
+	}
+	for _, rgx := range i.authors {
+		if rgx.MatchString(author) {
+			return true
+		}
+	}
+	return false
+}
+
+func (i *Ignore) compile() (err error) {
+	i.messages, err = compileAll(i.Messages)
+	if err != nil {
+		return fmt.Errorf("ignore messages: %w", err)
+	}
+	i.authors, err = compileAll(i.Authors)
+	if err != nil {
+		return fmt.Errorf("ignore authors: %w", err)
+	}
+	return nil
+}
+
+func compileAll(patterns []string) ([]*regexp.Regexp, error) {
+	result := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		rgx, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern: %w", err)
+		}
+		result = append(result, rgx)
+	}
+	return result, nil
+}
+
+var fixupPrefixes = []string{"fixup! ", "squash! ", "amend! "}
+
 // Config contains structure of configuration file.
 type Config struct {
+	Extends   string   `json:"extends,omitempty" yaml:"extends,omitempty" validate:"-" doc:"built-in preset name or path of extended configuration file"`
+	Replace   []string `json:"replace,omitempty" yaml:"replace,omitempty" validate:"-" doc:"keys of lists replacing the ones of extended and user-level configuration instead of being merged by name, e.g. args"`
 	Templates struct {
-		Commit    string `json:"commit" yaml:"commit" validate:"required"`
-		Changelog string `json:"changelog" yaml:"changelog" validate:"-"`
-	} `json:"templates" yaml:"templates"`
-	Args []struct {
-		Name     string   `json:"name,omitempty" yaml:"name" validate:"required"`
-		Options  []Option `json:"options,omitempty" yaml:"options" validate:"-"`
-		Required bool     `json:"required,omitempty" yaml:"required,omitempty" validate:"-"`
-		Width    int      `json:"width,omitempty" yaml:"width" validate:"omitempty,gte=0"`
-	} `json:"args,omitempty" yaml:"args" validate:"gt=0"`
+		Commit    string          `json:"commit" yaml:"commit" validate:"required" doc:"template of commit message created with prompt"`
+		Changelog string          `json:"changelog" yaml:"changelog" validate:"-" doc:"template of changelog with commits grouped by field values"`
+		Parse     []ParseTemplate `json:"parse,omitempty" yaml:"parse,omitempty" validate:"dive" doc:"additional templates of accepted commit messages"`
+	} `json:"templates" yaml:"templates" doc:"commit message and changelog templates"`
+	Args []Arg `json:"args,omitempty" yaml:"args" validate:"gt=0,dive" doc:"commit message template fields"`
+	Tag  struct {
+		Prefix string `json:"prefix,omitempty" yaml:"prefix,omitempty" validate:"-" doc:"prefix of version tags"`
+		Pre    string `json:"pre,omitempty" yaml:"pre,omitempty" validate:"-" doc:"pre-release channel used when --pre flag is not set"`
+	} `json:"tag,omitempty" yaml:"tag,omitempty" doc:"version tags"`
+	History struct {
+		Depth int `json:"depth,omitempty" yaml:"depth,omitempty" validate:"omitempty,gte=0" doc:"number of recent commits with values suggested for free-text args, 0 disables suggestions"`
+	} `json:"history,omitempty" yaml:"history,omitempty" doc:"values used in previous commits"`
+	Lint   map[string]LintRule `json:"lint,omitempty" yaml:"lint,omitempty" validate:"dive" doc:"lint rules by identifier"`
+	Ignore Ignore              `json:"ignore,omitempty" yaml:"ignore,omitempty" doc:"commits skipped by verify, changelog and version change"`
+	Forge  struct {
+		Kind   string `json:"kind,omitempty" yaml:"kind,omitempty" validate:"omitempty,oneof=github gitlab gitea bitbucket" doc:"hosting service, detected from URL by default"`
+		URL    string `json:"url,omitempty" yaml:"url,omitempty" validate:"omitempty,url" doc:"repository base URL, derived from remote URL by default"`
+		Remote string `json:"remote,omitempty" yaml:"remote,omitempty" validate:"-" doc:"remote used when URL is not set"`
+	} `json:"forge,omitempty" yaml:"forge,omitempty" doc:"repository hosting service used in changelog links"`
 }
 
+// RequiredArgs returns names of required args without conditions, which are
+// required in every message.
 func (c *Config) RequiredArgs() (r []string) {
 	for _, arg := range c.Args {
-		if arg.Required {
+		if arg.Required && len(arg.When) == 0 {
 			r = append(r, arg.Name)
 		}
 	}
 	return
 }
 
-// NewFromFile returns configuration from file with given path.
+// NewFromFile returns configuration from file with given path merged with
+// built-in defaults.
 func NewFromFile(path string) (*Config, error) {
-	cfg := &Config{}
+	layer, err := defaults()
+	if err != nil {
+		return nil, err
+	}
+	fileLayer, err := readLayer(path)
+	if err != nil {
+		return nil, err
+	}
+	return newFromLayer(merge(layer, fileLayer))
+}
 
-	if err := NewDecoder(path).Decode(cfg); err != nil {
-		return nil, fmt.Errorf("new decoder decode: %w", err)
+func (c *Config) validate() error {
+	if err := validator.New().Struct(c); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+	for _, arg := range c.AllArgs() {
+		if arg.OptionsFrom != nil {
+			if err := arg.OptionsFrom.validate(); err != nil {
+				return fmt.Errorf("invalid configuration: arg %s: %w", arg.Name, err)
+			}
+		}
+		if arg.Pattern == "" {
+			continue
+		}
+		if _, err := arg.compile(); err != nil {
+			return fmt.Errorf("invalid configuration: %w", err)
+		}
 	}
-	if err := validator.New().Struct(cfg); err != nil {
-		return nil, fmt.Errorf("invalid configuration: %w", err)
+	if err := c.Ignore.compile(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
 	}
-	return cfg, nil
+	return nil
 }
+
+const maxSuggestionDistance = 2
@@ -0,0 +1,251 @@
+package config
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestArg_Validate(t *testing.T) {
+	tests := []struct {
+		name           string
+		arg            Arg
+		value          string
+		wantRule       string
+		wantSuggestion string
+	}{
+		{
+			name:     "missing required",
+			arg:      Arg{Name: "Scope", Required: true},
+			value:    "",
+			wantRule: RuleRequired,
+		},
+		{
+			name:  "empty optional",
+			arg:   Arg{Name: "Task", Pattern: `^[A-Z]+-\d+$`},
+			value: "",
+		},
+		{
+			name:  "matches pattern",
+			arg:   Arg{Name: "Scope", Pattern: `^[a-z][a-z0-9-]*$`},
+			value: "api-v2",
+		},
+		{
+			name:     "mismatches pattern",
+			arg:      Arg{Name: "Task", Pattern: `^[A-Z]+-\d+$`},
+			value:    "task-1",
+			wantRule: RulePattern,
+		},
+		{
+			name:     "too short",
+			arg:      Arg{Name: "Message", MinLength: 3},
+			value:    "ab",
+			wantRule: RuleMinLength,
+		},
+		{
+			name:     "too long counted in characters",
+			arg:      Arg{Name: "Message", MaxLength: 3},
+			value:    "żółw",
+			wantRule: RuleMaxLength,
+		},
+		{
+			name:  "within length counted in characters",
+			arg:   Arg{Name: "Message", MaxLength: 4},
+			value: "żółw",
+		},
+		{
+			name: "not one of options",
+			arg: Arg{
+				Name:    "Type",
+				Options: []Option{{Value: "feat"}, {Value: "fix"}},
+			},
+			value:          "feet",
+			wantRule:       RuleEnum,
+			wantSuggestion: "feat",
+		},
+		{
+			name: "one of options",
+			arg: Arg{
+				Name:    "Type",
+				Options: []Option{{Value: "feat"}, {Value: "fix"}},
+			},
+			value: "fix",
+		},
+		{
+			name: "not one of options with enum disabled",
+			arg: Arg{
+				Name:            "Type",
+				Options:         []Option{{Value: "feat"}, {Value: "fix"}},
+				EnumFromOptions: new(bool),
+			},
+			value: "feet",
+		},
+		{
+			name: "custom value",
+			arg: Arg{
+				Name:        "Type",
+				Options:     []Option{{Value: "feat"}, {Value: "fix"}},
+				AllowCustom: true,
+			},
+			value: "perf",
+		},
+		{
+			name: "custom value mismatches pattern",
+			arg: Arg{
+				Name:        "Type",
+				Options:     []Option{{Value: "feat"}, {Value: "fix"}},
+				AllowCustom: true,
+				Pattern:     `^[a-z]+$`,
+			},
+			value:    "Perf",
+			wantRule: RulePattern,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.arg.Validate(tt.value)
+			if tt.wantRule == "" {
+				if err != nil {
+					t.Fatalf("Arg.Validate() error = %v", err)
+				}
+				return
+			}
+
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("Arg.Validate() error = %v, want rule %s", err, tt.wantRule)
+			}
+			if verr.Rule != tt.wantRule || verr.Arg != tt.arg.Name ||
+				verr.Suggestion != tt.wantSuggestion {
+				t.Fatalf("Arg.Validate() error = %+v, want rule %s", verr, tt.wantRule)
+			}
+		})
+	}
+}
+
+func TestArg_IsCustom(t *testing.T) {
+	options := []Option{{Value: "feat"}, {Value: "fix"}}
+	tests := []struct {
+		name  string
+		arg   Arg
+		value string
+		want  bool
+	}{
+		{name: "custom", arg: Arg{Options: options, AllowCustom: true}, value: "perf", want: true},
+		{name: "option", arg: Arg{Options: options, AllowCustom: true}, value: "fix"},
+		{name: "empty", arg: Arg{Options: options, AllowCustom: true}, value: ""},
+		{name: "custom not allowed", arg: Arg{Options: options}, value: "perf"},
+		{name: "enum disabled", arg: Arg{Options: options, AllowCustom: true, EnumFromOptions: new(bool)}, value: "perf"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.arg.IsCustom(tt.value); got != tt.want {
+				t.Fatalf("Arg.IsCustom() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIgnore_Match(t *testing.T) {
+	ignore := Ignore{
+		Messages: []string{`^chore\(release\)`},
+		Authors:  []string{`^dependabot\[bot\]`},
+		Merges:   true,
+		Fixups:   true,
+	}
+	if err := ignore.compile(); err != nil {
+		t.Fatalf("Ignore.compile() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		message string
+		author  string
+		merge   bool
+		want    bool
+	}{
+		{name: "regular", message: "feat(api): add endpoint", author: "Jane <jane@example.com>"},
+		{name: "merge", message: "Merge pull request #1 from x/y", merge: true, want: true},
+		{name: "fixup", message: "fixup! feat(api): add endpoint", want: true},
+		{name: "squash", message: "squash! feat(api): add endpoint", want: true},
+		{name: "release", message: "chore(release): v1.2.0", want: true},
+		{name: "bot", message: "build(deps): bump", author: "dependabot[bot] <support@github.com>", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ignore.Match(tt.message, tt.author, tt.merge); got != tt.want {
+				t.Fatalf("Ignore.Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfig_Walk(t *testing.T) {
+	cfg := &Config{Args: []Arg{
+		{Name: "Type", Options: []Option{
+			{Value: "feat!", Args: []Arg{{Name: "BreakingDescription"}}},
+			{Value: "feat"},
+			{Value: "docs"},
+		}},
+		{Name: "Scope", Options: []Option{
+			{Value: "api", When: Condition{"Type": {"feat", "feat!"}}},
+			{Value: "readme", When: Condition{"Type": {"docs"}}},
+		}},
+		{Name: "Task", When: Condition{"Type": {"feat", "fix"}}},
+	}}
+
+	tests := []struct {
+		name        string
+		values      map[string]string
+		wantArgs    []string
+		wantOptions []string
+	}{
+		{
+			name:        "nested args of selected option",
+			values:      map[string]string{"Type": "feat!"},
+			wantArgs:    []string{"Type", "BreakingDescription", "Scope"},
+			wantOptions: []string{"api"},
+		},
+		{
+			name:        "conditional arg",
+			values:      map[string]string{"Type": "feat"},
+			wantArgs:    []string{"Type", "Scope", "Task"},
+			wantOptions: []string{"api"},
+		},
+		{
+			name:        "skipped arg",
+			values:      map[string]string{"Type": "docs"},
+			wantArgs:    []string{"Type", "Scope"},
+			wantOptions: []string{"readme"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			var gotOptions []string
+			_ = cfg.Walk(tt.values, func(arg *Arg) error {
+				got = append(got, arg.Name)
+				if arg.Name == "Scope" {
+					gotOptions = arg.WithValues(tt.values).OptionValues()
+				}
+				return nil
+			})
+			if !slices.Equal(got, tt.wantArgs) {
+				t.Errorf("Walk() args = %v, want %v", got, tt.wantArgs)
+			}
+			if !slices.Equal(gotOptions, tt.wantOptions) {
+				t.Errorf("WithValues() options = %v, want %v", gotOptions, tt.wantOptions)
+			}
+		})
+	}
+
+	if _, ok := cfg.Arg("BreakingDescription"); !ok {
+		t.Errorf("Arg() nested arg not found")
+	}
+	if required := (&Config{Args: []Arg{
+		{Name: "Type", Required: true},
+		{Name: "Task", Required: true, When: Condition{"Type": {"feat"}}},
+	}}).RequiredArgs(); !slices.Equal(required, []string{"Type"}) {
+		t.Errorf("RequiredArgs() = %v, want only unconditional args", required)
+	}
+}
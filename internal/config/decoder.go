@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/BurntSushi/toml"
 	"gopkg.in/yaml.v3"
 )
 
@@ -14,6 +15,7 @@ import (
 // Supported extensions:
 // - yaml, yml
 // - json
+// - toml
 type Decoder struct {
 	filename string
 }
@@ -40,9 +42,11 @@ func (d *Decoder) Decode(v any) error {
 		dec = yaml.NewDecoder(file)
 	case ".json":
 		dec = json.NewDecoder(file)
+	case ".toml":
+		dec = tomlDecoder{toml.NewDecoder(file)}
 	default:
 		return fmt.Errorf(
-			"unsupported config file type: %s\nexpected: .yaml, .yml, .json", ext,
+			"unsupported config file type: %s\nexpected: .yaml, .yml, .json, .toml", ext,
 		)
 	}
 
@@ -56,3 +60,39 @@ func (d *Decoder) Decode(v any) error {
 type fileDecoder interface {
 	Decode(interface{}) error
 }
+
+// tomlDecoder decodes arrays of tables into lists of maps, like other
+// decoders, so they are merged as named lists.
+type tomlDecoder struct {
+	dec *toml.Decoder
+}
+
+func (d tomlDecoder) Decode(v interface{}) error {
+	if _, err := d.dec.Decode(v); err != nil {
+		return err
+	}
+	if m, ok := v.(*map[string]any); ok {
+		*m = normalizeTOML(*m).(map[string]any)
+	}
+	return nil
+}
+
+func normalizeTOML(v any) any {
+	switch typed := v.(type) {
+	case map[string]any:
+		for key, value := range typed {
+			typed[key] = normalizeTOML(value)
+		}
+	case []map[string]any:
+		list := make([]any, 0, len(typed))
+		for _, item := range typed {
+			list = append(list, normalizeTOML(item))
+		}
+		return list
+	case []any:
+		for i, item := range typed {
+			typed[i] = normalizeTOML(item)
+		}
+	}
+	return v
+}
@@ -0,0 +1,409 @@
+package config
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Options specifies sources of configuration layers. Layers are merged with
+// precedence: overrides > environment > repository file > user file >
+// built-in defaults.
+type Options struct {
+	// Path is an explicit repository configuration file. It disables the
+	// discovery.
+	Path string
+	// Dir is a directory where discovery of repository configuration file
+	// starts.
+	Dir string
+	// Env are environment variables in "KEY=value" form, only GOVER_* ones
+	// override configuration.
+	Env []string
+	// Overrides are values in "key.path=value" form, e.g. set with flags.
+	Overrides []string
+	// SkipCommands disables commands of arg options sources, e.g. in hooks.
+	// Values of args with such sources aren't checked against options.
+	SkipCommands bool
+}
+
+// Load returns configuration merged from all layers.
+func Load(opts Options) (*Config, error) {
+	repoFile := opts.Path
+	if repoFile == "" {
+		var err error
+		repoFile, err = Discover(opts.Dir)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+	}
+
+	files := []string{}
+	if userFile := UserFile(); userFile != "" {
+		if _, err := os.Stat(userFile); err == nil {
+			files = append(files, userFile)
+		}
+	}
+	if repoFile != "" {
+		files = append(files, repoFile)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf(
+			"%w: %s in %s or its parents", ErrNotFound, discoveredNames[0], opts.Dir,
+		)
+	}
+
+	layer, err := defaults()
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		fileLayer, err := readLayer(file)
+		if err != nil {
+			return nil, err
+		}
+		layer = merge(layer, fileLayer)
+	}
+
+	overrides, err := envOverrides(opts.Env, layer)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range opts.Overrides {
+		o, err := ParseOverride(s)
+		if err != nil {
+			return nil, err
+		}
+		overrides = append(overrides, o)
+	}
+	for _, o := range overrides {
+		if err := applyOverride(layer, o); err != nil {
+			return nil, err
+		}
+	}
+
+	return newFromLayer(layer)
+}
+
+// Discover returns path of repository configuration file found in given
+// directory or the closest of its parents. Discovery stops at repository
+// root, the directory with ".git", so files in its parents, e.g. in home
+// directory, are not used.
+func Discover(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("absolute path: %w", err)
+	}
+	for {
+		if path, found := Lookup(dir); found {
+			return path, nil
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return "", ErrNotFound
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", ErrNotFound
+		}
+		dir = parent
+	}
+}
+
+// Lookup returns path of repository configuration file in given directory,
+// without looking into its parents.
+func Lookup(dir string) (string, bool) {
+	for _, name := range discoveredNames {
+		path := filepath.Join(dir, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// UserFile returns path of user-level configuration file:
+// $XDG_CONFIG_HOME/gover/config.yml, which defaults to
+// ~/.config/gover/config.yml.
+func UserFile() string {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "gover", "config.yml")
+}
+
+// discoveredNames are names of repository configuration files in order of
+// lookup in each directory.
+var discoveredNames = []string{
+	FileName,
+	".gover.yml",
+	filepath.Join(".config", "gover.yml"),
+	"gover.toml",
+	".gover.toml",
+	filepath.Join(".config", "gover.toml"),
+}
+
+//go:embed defaults.yml
+var defaultsFile []byte
+
+func defaults() (map[string]any, error) {
+	layer := map[string]any{}
+	if err := yaml.Unmarshal(defaultsFile, &layer); err != nil {
+		return nil, fmt.Errorf("decode defaults: %w", err)
+	}
+	return layer, nil
+}
+
+// presets are built-in configurations, which can be extended with
+// "extends: <name>".
+//
+//go:embed presets/*.yml
+var presets embed.FS
+
+// Presets returns names of built-in configuration presets.
+func Presets() []string {
+	entries, _ := fs.ReadDir(presets, presetsDir)
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, strings.TrimSuffix(e.Name(), presetExt))
+	}
+	return names
+}
+
+// PresetDescription returns first comment line of the preset.
+func PresetDescription(name string) string {
+	content, err := presets.ReadFile(presetsDir + "/" + name + presetExt)
+	if err != nil {
+		return ""
+	}
+	line, _, _ := strings.Cut(string(content), "\n")
+	return strings.TrimSpace(strings.TrimPrefix(line, "#"))
+}
+
+// NewFromBytes returns configuration decoded from YAML content merged over
+// built-in defaults. Relative "extends" paths are resolved against dir.
+func NewFromBytes(content []byte, dir string) (*Config, error) {
+	layer := map[string]any{}
+	if err := yaml.Unmarshal(content, &layer); err != nil {
+		return nil, fmt.Errorf("decode configuration: %w", err)
+	}
+	layer, err := extend(layer, dir, map[string]bool{})
+	if err != nil {
+		return nil, err
+	}
+	base, err := defaults()
+	if err != nil {
+		return nil, err
+	}
+	return newFromLayer(merge(base, layer))
+}
+
+// readLayer returns configuration decoded from file with resolved
+// "extends" key.
+func readLayer(path string) (map[string]any, error) {
+	return readExtendedLayer(path, map[string]bool{})
+}
+
+func readExtendedLayer(path string, visited map[string]bool) (map[string]any, error) {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if visited[path] {
+		return nil, fmt.Errorf("%w: %s", ErrExtendsCycle, path)
+	}
+	visited[path] = true
+
+	layer := map[string]any{}
+	if err := NewDecoder(path).Decode(&layer); err != nil {
+		return nil, fmt.Errorf("new decoder decode: %w", err)
+	}
+	return extend(layer, filepath.Dir(path), visited)
+}
+
+func readPreset(name string, visited map[string]bool) (map[string]any, error) {
+	key := "preset:" + name
+	if visited[key] {
+		return nil, fmt.Errorf("%w: %s", ErrExtendsCycle, name)
+	}
+	visited[key] = true
+
+	content, err := presets.ReadFile(presetsDir + "/" + name + presetExt)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"unknown preset %s, expected one of: %s",
+			name, strings.Join(Presets(), ", "),
+		)
+	}
+	layer := map[string]any{}
+	if err := yaml.Unmarshal(content, &layer); err != nil {
+		return nil, fmt.Errorf("decode preset %s: %w", name, err)
+	}
+	return extend(layer, "", visited)
+}
+
+// extend merges layer over configuration it extends. Extended configuration
+// is a built-in preset name or path of the file relative to dir.
+func extend(layer map[string]any, dir string, visited map[string]bool) (map[string]any, error) {
+	extends, _ := layer[extendsKey].(string)
+	delete(layer, extendsKey)
+	if extends == "" {
+		return layer, nil
+	}
+
+	var (
+		base map[string]any
+		err  error
+	)
+	if isPresetName(extends) {
+		base, err = readPreset(extends, visited)
+	} else {
+		if !filepath.IsAbs(extends) {
+			extends = filepath.Join(dir, extends)
+		}
+		base, err = readExtendedLayer(extends, visited)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("extends: %w", err)
+	}
+	return merge(base, layer), nil
+}
+
+// isPresetName returns true if extended configuration is not a file path.
+func isPresetName(extends string) bool {
+	return !strings.ContainsAny(extends, `/\`) && filepath.Ext(extends) == ""
+}
+
+// FileName is a default name of repository configuration file.
+const FileName = "gover.yml"
+
+const (
+	extendsKey = "extends"
+	presetsDir = "presets"
+	presetExt  = ".yml"
+)
+
+// newFromLayer decodes merged layers into validated configuration.
+func newFromLayer(layer map[string]any) (*Config, error) {
+	cfg, err := decodeLayer(layer)
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// decodeLayer decodes merged layers into configuration without validation.
+func decodeLayer(layer map[string]any) (*Config, error) {
+	content, err := yaml.Marshal(layer)
+	if err != nil {
+		return nil, fmt.Errorf("encode merged configuration: %w", err)
+	}
+
+	cfg := &Config{}
+	if err := yaml.Unmarshal(content, cfg); err != nil {
+		return nil, fmt.Errorf("decode merged configuration: %w", err)
+	}
+	return cfg, nil
+}
+
+// merge returns dst with values of src deeply merged into it. Maps are merged
+// recursively, lists of named items, like args, are merged by name, unless
+// listed in "replace" key of src, and any other value of src replaces the one
+// in dst.
+func merge(dst, src map[string]any) map[string]any {
+	if dst == nil {
+		dst = map[string]any{}
+	}
+	replaced := replacedKeys(src)
+	for key, srcValue := range src {
+		if key == replaceKey {
+			continue
+		}
+		if replaced[key] {
+			dst[key] = srcValue
+			continue
+		}
+		switch srcTyped := srcValue.(type) {
+		case map[string]any:
+			if dstTyped, ok := dst[key].(map[string]any); ok {
+				dst[key] = merge(dstTyped, srcTyped)
+				continue
+			}
+		case []any:
+			if dstTyped, ok := dst[key].([]any); ok && isNamedList(dstTyped) && isNamedList(srcTyped) {
+				dst[key] = mergeNamed(dstTyped, srcTyped)
+				continue
+			}
+		}
+		dst[key] = srcValue
+	}
+	return dst
+}
+
+// replacedKeys returns keys listed in "replace" key of the layer.
+func replacedKeys(layer map[string]any) map[string]bool {
+	keys := map[string]bool{}
+	list, _ := layer[replaceKey].([]any)
+	for _, key := range list {
+		if k, ok := key.(string); ok {
+			keys[k] = true
+		}
+	}
+	return keys
+}
+
+func mergeNamed(dst, src []any) []any {
+	index := map[any]int{}
+	for i, item := range dst {
+		index[item.(map[string]any)[nameKey]] = i
+	}
+	for _, item := range src {
+		srcItem := item.(map[string]any)
+		if i, found := index[srcItem[nameKey]]; found {
+			dst[i] = merge(dst[i].(map[string]any), srcItem)
+			continue
+		}
+		dst = append(dst, srcItem)
+	}
+	return dst
+}
+
+func isNamedList(list []any) bool {
+	for _, item := range list {
+		m, ok := item.(map[string]any)
+		if !ok {
+			return false
+		}
+		if _, ok := m[nameKey]; !ok {
+			return false
+		}
+	}
+	return len(list) != 0
+}
+
+const (
+	nameKey    = "name"
+	replaceKey = "replace"
+)
+
+var (
+	// ErrNotFound indicates missing configuration file.
+	ErrNotFound = errors.New("configuration file not found")
+	// ErrExtendsCycle indicates configuration that extends itself.
+	ErrExtendsCycle = errors.New("extends cycle")
+	// ErrInvalidOverride indicates override of unknown key or with invalid
+	// value.
+	ErrInvalidOverride = errors.New("invalid override")
+)
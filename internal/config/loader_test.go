@@ -0,0 +1,255 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	dst := map[string]any{
+		"templates": map[string]any{"commit": "{{.Type}}: {{.Message}}", "changelog": "log"},
+		"args": []any{
+			map[string]any{"name": "Type", "required": true},
+			map[string]any{"name": "Message"},
+		},
+		"lint": map[string]any{"forbidden-words": map[string]any{"words": []any{"WIP"}}},
+	}
+	src := map[string]any{
+		"templates": map[string]any{"commit": "{{.Type}}({{.Scope}}): {{.Message}}"},
+		"args": []any{
+			map[string]any{"name": "Message", "required": true},
+			map[string]any{"name": "Scope"},
+		},
+		"lint": map[string]any{"forbidden-words": map[string]any{"words": []any{"TODO"}}},
+	}
+	want := map[string]any{
+		"templates": map[string]any{"commit": "{{.Type}}({{.Scope}}): {{.Message}}", "changelog": "log"},
+		"args": []any{
+			map[string]any{"name": "Type", "required": true},
+			map[string]any{"name": "Message", "required": true},
+			map[string]any{"name": "Scope"},
+		},
+		"lint": map[string]any{"forbidden-words": map[string]any{"words": []any{"TODO"}}},
+	}
+
+	if got := merge(dst, src); !reflect.DeepEqual(got, want) {
+		t.Fatalf("merge() = %v, want %v", got, want)
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "a", "b")
+	if err := os.MkdirAll(filepath.Join(root, "a", ".config"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Discover(nested); err == nil {
+		t.Fatalf("Discover() error = nil, want not found")
+	}
+
+	writeFile(t, filepath.Join(root, "gover.yml"), "")
+	writeFile(t, filepath.Join(root, "a", ".config", "gover.yml"), "")
+
+	got, err := Discover(nested)
+	if err != nil {
+		t.Fatalf("Discover() error = %v", err)
+	}
+	if want := filepath.Join(root, "a", ".config", "gover.yml"); got != want {
+		t.Fatalf("Discover() = %v, want %v", got, want)
+	}
+
+	// Files above repository root are not used.
+	if err := os.MkdirAll(filepath.Join(nested, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Discover(nested); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Discover() error = %v, want %v", err, ErrNotFound)
+	}
+
+	// YAML files are preferred to TOML ones in the same directory.
+	writeFile(t, filepath.Join(nested, "gover.toml"), "")
+	got, err = Discover(nested)
+	if err != nil {
+		t.Fatalf("Discover() error = %v", err)
+	}
+	if want := filepath.Join(nested, "gover.toml"); got != want {
+		t.Fatalf("Discover() = %v, want %v", got, want)
+	}
+	writeFile(t, filepath.Join(nested, ".gover.yml"), "")
+	got, err = Discover(nested)
+	if err != nil {
+		t.Fatalf("Discover() error = %v", err)
+	}
+	if want := filepath.Join(nested, ".gover.yml"); got != want {
+		t.Fatalf("Discover() = %v, want %v", got, want)
+	}
+}
+
+func TestMerge_Replace(t *testing.T) {
+	dst := map[string]any{
+		"args": []any{
+			map[string]any{"name": "Ticket", "required": true},
+			map[string]any{"name": "Message"},
+		},
+		"ignore": map[string]any{"messages": []any{"^WIP"}},
+	}
+	src := map[string]any{
+		"replace": []any{"args"},
+		"args": []any{
+			map[string]any{"name": "Type"},
+			map[string]any{"name": "Message", "required": true},
+		},
+		"ignore": map[string]any{"fixups": true},
+	}
+	want := map[string]any{
+		"args": []any{
+			map[string]any{"name": "Type"},
+			map[string]any{"name": "Message", "required": true},
+		},
+		"ignore": map[string]any{"messages": []any{"^WIP"}, "fixups": true},
+	}
+
+	if got := merge(dst, src); !reflect.DeepEqual(got, want) {
+		t.Fatalf("merge() = %v, want %v", got, want)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(root, "xdg"))
+
+	writeFile(t, filepath.Join(root, "xdg", "gover", "config.yml"), `
+templates:
+  commit: "{{.Type}}: {{.Message}}"
+args:
+  - name: Type
+    required: true
+  - name: Message
+    required: true
+forge:
+  kind: github
+`)
+	writeFile(t, filepath.Join(root, "repo", ".gover.yml"), `
+args:
+  - name: Type
+    options:
+      - value: feat
+        version: minor
+forge:
+  url: https://github.com/kam9lo/gover
+`)
+
+	cfg, err := Load(Options{Dir: filepath.Join(root, "repo")})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Templates.Commit != "{{.Type}}: {{.Message}}" {
+		t.Errorf("Load() commit template = %q", cfg.Templates.Commit)
+	}
+	if len(cfg.Args) != 2 || !cfg.Args[0].Required || len(cfg.Args[0].Options) != 1 {
+		t.Errorf("Load() args = %+v", cfg.Args)
+	}
+	if cfg.Forge.Kind != "github" || cfg.Forge.URL != "https://github.com/kam9lo/gover" {
+		t.Errorf("Load() forge = %+v", cfg.Forge)
+	}
+	if cfg.Forge.Remote != "origin" {
+		t.Errorf("Load() default forge remote = %q", cfg.Forge.Remote)
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPresets(t *testing.T) {
+	for _, name := range Presets() {
+		t.Run(name, func(t *testing.T) {
+			layer, err := readPreset(name, map[string]bool{})
+			if err != nil {
+				t.Fatalf("readPreset() error = %v", err)
+			}
+			if _, err := newFromLayer(layer); err != nil {
+				t.Fatalf("newFromLayer() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestNewFromFile_Extends(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "shared", "gover-base.yml"), `
+extends: conventional
+args:
+  - name: Task
+    pattern: ^[A-Z]+-\d+$
+`)
+	writeFile(t, filepath.Join(root, "repo", "gover.yml"), `
+extends: ../shared/gover-base.yml
+args:
+  - name: Scope
+    required: true
+`)
+	writeFile(t, filepath.Join(root, "cycle.yml"), `extends: ./cycle.yml`)
+
+	cfg, err := NewFromFile(filepath.Join(root, "repo", "gover.yml"))
+	if err != nil {
+		t.Fatalf("NewFromFile() error = %v", err)
+	}
+	args := map[string]Arg{}
+	for _, arg := range cfg.Args {
+		args[arg.Name] = arg
+	}
+	if len(args["Type"].Options) == 0 {
+		t.Errorf("NewFromFile() Type options not extended from preset")
+	}
+	if !args["Scope"].Required || args["Scope"].Pattern == "" {
+		t.Errorf("NewFromFile() Scope = %+v, want required with preset pattern", args["Scope"])
+	}
+	if args["Task"].Pattern != `^[A-Z]+-\d+$` {
+		t.Errorf("NewFromFile() Task = %+v, want shared pattern", args["Task"])
+	}
+
+	if _, err := NewFromFile(filepath.Join(root, "cycle.yml")); !errors.Is(err, ErrExtendsCycle) {
+		t.Errorf("NewFromFile() error = %v, want %v", err, ErrExtendsCycle)
+	}
+}
+
+func TestNewFromBytes(t *testing.T) {
+	cfg, err := NewFromBytes([]byte(`
+extends: simple
+tag:
+  prefix: release-
+args:
+  - name: Message
+    required: false
+`), t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFromBytes() error = %v", err)
+	}
+	if cfg.Tag.Prefix != "release-" {
+		t.Errorf("NewFromBytes() tag prefix = %q, want %q", cfg.Tag.Prefix, "release-")
+	}
+	if arg, ok := cfg.Arg("Message"); !ok || arg.Required {
+		t.Errorf("NewFromBytes() Message = %+v, want optional", arg)
+	}
+	if cfg.Forge.Remote != "origin" {
+		t.Errorf("NewFromBytes() forge remote = %q, want default", cfg.Forge.Remote)
+	}
+
+	if _, err := NewFromBytes([]byte(`extends: unknown`), t.TempDir()); err == nil {
+		t.Errorf("NewFromBytes() expected error for unknown preset")
+	}
+}
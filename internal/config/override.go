@@ -0,0 +1,302 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Override is a value of configuration key path, e.g. "tag.prefix" or
+// "args.Scope.required". Named list items, like args, are selected by name
+// and map entries, like lint rules, by key.
+type Override struct {
+	Path  []string
+	Value string
+}
+
+// ParseOverride parses "key.path=value" override.
+func ParseOverride(s string) (Override, error) {
+	key, value, ok := strings.Cut(s, "=")
+	if !ok || key == "" {
+		return Override{}, fmt.Errorf("%w: %q, expected key.path=value", ErrInvalidOverride, s)
+	}
+	return Override{Path: strings.Split(key, "."), Value: value}, nil
+}
+
+// EnvPrefix is a prefix of environment variables overriding configuration.
+const EnvPrefix = "GOVER_"
+
+// ArgEnvPrefix is a prefix of environment variables with arg values of
+// non-interactive commit, e.g. GOVER_ARG_TYPE=feat. They aren't overrides.
+const ArgEnvPrefix = EnvPrefix + "ARG_"
+
+// envOverrides returns overrides from GOVER_* environment variables, which
+// name key path in upper case with keys and dashes replaced by underscores,
+// e.g. GOVER_TAG_PREFIX or GOVER_LINT_SUBJECT_MAX_LENGTH_MAX. Variables not
+// matching any key are rejected, so typos don't go unnoticed.
+func envOverrides(environ []string, layer map[string]any) ([]Override, error) {
+	var overrides []Override
+	for _, env := range environ {
+		name, value, _ := strings.Cut(env, "=")
+		if strings.HasPrefix(name, ArgEnvPrefix) {
+			continue
+		}
+		key, found := strings.CutPrefix(name, EnvPrefix)
+		if !found {
+			continue
+		}
+		path, ok := envPath(key, configType, layer)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s doesn't match any key", ErrInvalidOverride, name)
+		}
+		overrides = append(overrides, Override{Path: path, Value: value})
+	}
+	return overrides, nil
+}
+
+var configType = reflect.TypeOf(Config{})
+
+// envPath resolves environment variable name into key path of struct type.
+func envPath(name string, t reflect.Type, node map[string]any) ([]string, bool) {
+	t = indirect(t)
+	if t.Kind() != reflect.Struct {
+		return nil, false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		key, ok := yamlKey(t.Field(i))
+		if !ok {
+			continue
+		}
+		if name == envName(key) {
+			return []string{key}, true
+		}
+		rest, found := strings.CutPrefix(name, envName(key)+"_")
+		if !found {
+			continue
+		}
+
+		ft := indirect(t.Field(i).Type)
+		child, _ := node[key].(map[string]any)
+		var path []string
+		ok = false
+		switch {
+		case ft.Kind() == reflect.Struct:
+			path, ok = envPath(rest, ft, child)
+		case ft.Kind() == reflect.Map:
+			path, ok = envItemPath(rest, ft.Elem(), keysOf(child), mapKey)
+		case isNamedSlice(ft):
+			list, _ := node[key].([]any)
+			path, ok = envItemPath(rest, ft.Elem(), namesOf(list), nil)
+		}
+		if ok {
+			return append([]string{key}, path...), true
+		}
+	}
+	return nil, false
+}
+
+// envItemPath resolves name of map entry or named list item followed by its
+// key path. Known keys are matched first, otherwise fallback converts the
+// name prefix into the key.
+func envItemPath(name string, elem reflect.Type, keys []string, fallback func(string) string) ([]string, bool) {
+	resolve := func(key, rest string) ([]string, bool) {
+		if indirect(elem).Kind() != reflect.Struct {
+			return []string{key}, rest == ""
+		}
+		path, ok := envPath(rest, elem, nil)
+		return append([]string{key}, path...), ok
+	}
+
+	for _, key := range keys {
+		if name == envName(key) {
+			return resolve(key, "")
+		}
+		if rest, found := strings.CutPrefix(name, envName(key)+"_"); found {
+			if path, ok := resolve(key, rest); ok {
+				return path, true
+			}
+		}
+	}
+	if fallback == nil {
+		return nil, false
+	}
+	if indirect(elem).Kind() != reflect.Struct {
+		return []string{fallback(name)}, true
+	}
+	for i := strings.Index(name, "_"); i > 0; i = nextIndex(name, "_", i) {
+		if path, ok := resolve(fallback(name[:i]), name[i+1:]); ok {
+			return path, true
+		}
+	}
+	return nil, false
+}
+
+func nextIndex(s, sep string, after int) int {
+	i := strings.Index(s[after+1:], sep)
+	if i < 0 {
+		return -1
+	}
+	return after + 1 + i
+}
+
+func envName(key string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(key))
+}
+
+func mapKey(name string) string {
+	return strings.ReplaceAll(strings.ToLower(name), "_", "-")
+}
+
+// applyOverride sets override value in the layer. Value is decoded as YAML,
+// unless the key is a string.
+func applyOverride(layer map[string]any, o Override) error {
+	if err := setPath(layer, configType, o.Path, o.Value); err != nil {
+		return fmt.Errorf("%w %s: %w", ErrInvalidOverride, strings.Join(o.Path, "."), err)
+	}
+	return nil
+}
+
+func setPath(node map[string]any, t reflect.Type, path []string, raw string) error {
+	t = indirect(t)
+	field, found := fieldByKey(t, path[0])
+	if !found {
+		return fmt.Errorf("unknown key %s", path[0])
+	}
+	key, ft := path[0], indirect(field.Type)
+	if len(path) == 1 {
+		value, err := decodeValue(raw, ft)
+		if err != nil {
+			return err
+		}
+		node[key] = value
+		return nil
+	}
+
+	switch {
+	case ft.Kind() == reflect.Struct:
+		return setPath(childMap(node, key), ft, path[1:], raw)
+	case ft.Kind() == reflect.Map:
+		entries := childMap(node, key)
+		if len(path) == 2 {
+			value, err := decodeValue(raw, ft.Elem())
+			if err != nil {
+				return err
+			}
+			entries[path[1]] = value
+			return nil
+		}
+		return setPath(childMap(entries, path[1]), ft.Elem(), path[2:], raw)
+	case isNamedSlice(ft):
+		if len(path) == 2 {
+			return fmt.Errorf("missing key of %s item %s", key, path[1])
+		}
+		list, _ := node[key].([]any)
+		var item map[string]any
+		for _, i := range list {
+			if m, ok := i.(map[string]any); ok && m[nameKey] == path[1] {
+				item = m
+			}
+		}
+		if item == nil {
+			item = map[string]any{nameKey: path[1]}
+			node[key] = append(list, item)
+		}
+		return setPath(item, ft.Elem(), path[2:], raw)
+	default:
+		return fmt.Errorf("%s has no key %s", key, path[1])
+	}
+}
+
+func decodeValue(raw string, t reflect.Type) (any, error) {
+	if indirect(t).Kind() == reflect.String {
+		return raw, nil
+	}
+	var value any
+	if err := yaml.Unmarshal([]byte(raw), &value); err != nil {
+		return nil, fmt.Errorf("decode value: %w", err)
+	}
+	return value, nil
+}
+
+func childMap(node map[string]any, key string) map[string]any {
+	child, ok := node[key].(map[string]any)
+	if !ok {
+		child = map[string]any{}
+		node[key] = child
+	}
+	return child
+}
+
+func fieldByKey(t reflect.Type, key string) (reflect.StructField, bool) {
+	if t.Kind() != reflect.Struct {
+		return reflect.StructField{}, false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if k, ok := yamlKey(t.Field(i)); ok && k == key {
+			return t.Field(i), true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// yamlKey returns key of exported struct field in YAML document.
+func yamlKey(field reflect.StructField) (string, bool) {
+	if !field.IsExported() {
+		return "", false
+	}
+	key, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	switch key {
+	case "-":
+		return "", false
+	case "":
+		return strings.ToLower(field.Name), true
+	}
+	return key, true
+}
+
+// isNamedSlice returns true for lists of items with name, like args.
+func isNamedSlice(t reflect.Type) bool {
+	if t.Kind() != reflect.Slice {
+		return false
+	}
+	_, found := fieldByKey(indirect(t.Elem()), nameKey)
+	return found
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// keysOf returns keys of the map, the longest first, so they are matched
+// greedily.
+func keysOf(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+func namesOf(list []any) []string {
+	names := make([]string, 0, len(list))
+	for _, item := range list {
+		if m, ok := item.(map[string]any); ok {
+			if name, ok := m[nameKey].(string); ok {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
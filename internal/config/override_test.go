@@ -0,0 +1,150 @@
+package config
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestEnvOverrides(t *testing.T) {
+	layer := map[string]any{
+		"args": []any{
+			map[string]any{"name": "Scope"},
+			map[string]any{"name": "BreakingChange"},
+		},
+		"lint": map[string]any{
+			"subject-max-length": map[string]any{"max": 50},
+		},
+	}
+	environ := []string{
+		"HOME=/root",
+		"GOVER_TAG_PREFIX=release-",
+		"GOVER_TEMPLATES_COMMIT={{.Message}}",
+		"GOVER_ARGS_SCOPE_REQUIRED=true",
+		"GOVER_ARGS_BREAKINGCHANGE_MAXLENGTH=10",
+		"GOVER_LINT_SUBJECT_MAX_LENGTH_MAX=60",
+		"GOVER_LINT_BODY_LEADING_BLANK_SEVERITY=off",
+		"GOVER_ARG_TYPE=feat",
+	}
+	want := []Override{
+		{Path: []string{"tag", "prefix"}, Value: "release-"},
+		{Path: []string{"templates", "commit"}, Value: "{{.Message}}"},
+		{Path: []string{"args", "Scope", "required"}, Value: "true"},
+		{Path: []string{"args", "BreakingChange", "maxLength"}, Value: "10"},
+		{Path: []string{"lint", "subject-max-length", "max"}, Value: "60"},
+		{Path: []string{"lint", "body-leading-blank", "severity"}, Value: "off"},
+	}
+	got, err := envOverrides(environ, layer)
+	if err != nil {
+		t.Fatalf("envOverrides() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("envOverrides() =\n%v\nwant\n%v", got, want)
+	}
+
+	for _, env := range []string{
+		"GOVER_UNKNOWN=1",
+		"GOVER_TAG_PREFX=v",
+		"GOVER_ARGS_BREAKINGCHANGE_MAX_LENGTH=10",
+	} {
+		if _, err := envOverrides([]string{env}, layer); !errors.Is(err, ErrInvalidOverride) {
+			t.Errorf("envOverrides(%s) error = %v, want %v", env, err, ErrInvalidOverride)
+		}
+	}
+}
+
+func TestApplyOverride(t *testing.T) {
+	tests := []struct {
+		name     string
+		override string
+		want     map[string]any
+		wantErr  bool
+	}{
+		{
+			name:     "string value",
+			override: "tag.prefix=1",
+			want:     map[string]any{"tag": map[string]any{"prefix": "1"}},
+		},
+		{
+			name:     "named list item",
+			override: "args.Scope.required=true",
+			want: map[string]any{"args": []any{
+				map[string]any{"name": "Type"},
+				map[string]any{"name": "Scope", "required": true},
+			}},
+		},
+		{
+			name:     "map entry",
+			override: "lint.forbidden-words.words=[WIP, TODO]",
+			want: map[string]any{"lint": map[string]any{
+				"forbidden-words": map[string]any{"words": []any{"WIP", "TODO"}},
+			}},
+		},
+		{
+			name:     "unknown key",
+			override: "tag.suffix=x",
+			wantErr:  true,
+		},
+		{
+			name:     "missing item key",
+			override: "args.Scope=x",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			layer := map[string]any{"args": []any{map[string]any{"name": "Type"}}}
+			o, err := ParseOverride(tt.override)
+			if err != nil {
+				t.Fatalf("ParseOverride() error = %v", err)
+			}
+			err = applyOverride(layer, o)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidOverride) {
+					t.Errorf("applyOverride() error = %v, want %v", err, ErrInvalidOverride)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("applyOverride() error = %v", err)
+			}
+			for key, value := range tt.want {
+				if !reflect.DeepEqual(layer[key], value) {
+					t.Errorf("applyOverride() %s = %v, want %v", key, layer[key], value)
+				}
+			}
+		})
+	}
+}
+
+func TestLoad_Overrides(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(root, "xdg"))
+	path := filepath.Join(root, "gover.toml")
+	writeFile(t, path, `
+extends = "simple"
+
+[tag]
+prefix = "v"
+
+[[args]]
+name = "Message"
+maxLength = 50
+`)
+
+	cfg, err := Load(Options{
+		Path:      path,
+		Env:       []string{"GOVER_TAG_PREFIX=env-", "GOVER_TAG_PRE=rc"},
+		Overrides: []string{"tag.prefix=flag-"},
+	})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Tag.Prefix != "flag-" || cfg.Tag.Pre != "rc" {
+		t.Errorf("Load() tag = %+v, want flag prefix and env pre-release", cfg.Tag)
+	}
+	if arg, ok := cfg.Arg("Message"); !ok || arg.MaxLength != 50 || !arg.Required {
+		t.Errorf("Load() Message = %+v, want TOML merged with preset", arg)
+	}
+}
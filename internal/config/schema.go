@@ -0,0 +1,153 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Schema returns JSON Schema of configuration file generated from [Config]
+// type. Field descriptions come from "doc" tags, enums and constraints from
+// "validate" tags.
+//
+// Required fields and minimum number of items of top-level sections are not
+// enforced, as they may be defined in extended configuration.
+func Schema() ([]byte, error) {
+	g := &schemaGenerator{defs: map[string]*schema{}}
+	root := g.generate(reflect.TypeOf(Config{}), true)
+	root.Schema = schemaDraft
+	root.ID = schemaID
+	root.Title = "gover configuration"
+	root.Defs = g.defs
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(root); err != nil {
+		return nil, fmt.Errorf("encode schema: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+const (
+	schemaDraft = "https://json-schema.org/draft/2020-12/schema"
+	schemaID    = "https://raw.githubusercontent.com/kam9lo/gover/main/gover.schema.json"
+)
+
+type schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	ID                   string             `json:"$id,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Minimum              *int               `json:"minimum,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	Items                *schema            `json:"items,omitempty"`
+	Properties           map[string]*schema `json:"properties,omitempty"`
+	AdditionalProperties any                `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Defs                 map[string]*schema `json:"$defs,omitempty"`
+}
+
+type schemaGenerator struct {
+	defs map[string]*schema
+}
+
+// generate returns schema of the type. Named structs are generated once into
+// definitions and referenced, so recursive types are supported. Inherited
+// disables required constraints of structs, which may be defined in extended
+// configuration.
+func (g *schemaGenerator) generate(t reflect.Type, inherited bool) *schema {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return g.generate(t.Elem(), inherited)
+	case reflect.String:
+		return &schema{Type: "string"}
+	case reflect.Bool:
+		return &schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &schema{Type: "array", Items: g.generate(t.Elem(), false)}
+	case reflect.Map:
+		return &schema{Type: "object", AdditionalProperties: g.generate(t.Elem(), false)}
+	case reflect.Struct:
+		if t.Name() == "" || t == reflect.TypeOf(Config{}) {
+			return g.generateStruct(t, inherited)
+		}
+		ref := &schema{Ref: "#/$defs/" + t.Name()}
+		if _, found := g.defs[t.Name()]; !found {
+			// Placeholder stops recursion of self-referencing types.
+			g.defs[t.Name()] = &schema{}
+			*g.defs[t.Name()] = *g.generateStruct(t, false)
+		}
+		return ref
+	default:
+		return &schema{}
+	}
+}
+
+func (g *schemaGenerator) generateStruct(t reflect.Type, inherited bool) *schema {
+	s := &schema{
+		Type:                 "object",
+		Properties:           map[string]*schema{},
+		AdditionalProperties: false,
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, ok := yamlKey(field)
+		if !ok {
+			continue
+		}
+
+		fieldSchema := g.generate(field.Type, inherited)
+		fieldSchema.Description = field.Tag.Get("doc")
+		if applyValidateTag(fieldSchema, field.Tag.Get("validate"), inherited) {
+			s.Required = append(s.Required, name)
+		}
+		s.Properties[name] = fieldSchema
+	}
+	return s
+}
+
+// applyValidateTag sets constraints of validate tag on the schema and returns
+// true if field is required.
+func applyValidateTag(s *schema, tag string, inherited bool) (required bool) {
+	for _, rule := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+			required = !inherited
+		case "oneof":
+			s.Enum = strings.Fields(param)
+		case "url":
+			s.Format = "uri"
+		case "gte", "gt":
+			var limit int
+			if _, err := fmt.Sscan(param, &limit); err != nil {
+				continue
+			}
+			if name == "gt" {
+				limit++
+			}
+			switch s.Type {
+			case "integer":
+				s.Minimum = &limit
+			case "array":
+				if !inherited {
+					s.MinItems = &limit
+				}
+			}
+		}
+	}
+	return required
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+// schemaFile is the committed schema referenced by editors.
+const schemaFile = "../../gover.schema.json"
+
+func TestSchema_Generated(t *testing.T) {
+	want, err := Schema()
+	if err != nil {
+		t.Fatalf("Schema() error = %v", err)
+	}
+	got, err := os.ReadFile(schemaFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s is outdated, run: go run . config schema > gover.schema.json", schemaFile)
+	}
+}
+
+func TestSchema(t *testing.T) {
+	content, err := Schema()
+	if err != nil {
+		t.Fatalf("Schema() error = %v", err)
+	}
+	var s schema
+	if err := json.Unmarshal(content, &s); err != nil {
+		t.Fatalf("Schema() invalid JSON: %v", err)
+	}
+
+	arg := s.Defs["Arg"]
+	if arg == nil || len(arg.Required) != 1 || arg.Required[0] != "name" {
+		t.Errorf("Schema() Arg = %+v, want required name", arg)
+	}
+	version := s.Defs["Option"].Properties["version"]
+	if len(version.Enum) != 3 || version.Description == "" {
+		t.Errorf("Schema() Option version = %+v, want enum with description", version)
+	}
+	if len(s.Properties["templates"].Required) != 0 {
+		t.Errorf("Schema() templates required = %v, want none in extendable section", s.Properties["templates"].Required)
+	}
+	for name, property := range s.Properties {
+		if property.Description == "" {
+			t.Errorf("Schema() property %s has no description", name)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// OptionsSource provides arg options read at runtime, e.g. package names or
+// top-level directories. Exactly one of command, glob or file is set.
+type OptionsSource struct {
+	Command string `json:"command,omitempty" yaml:"command,omitempty" validate:"-" doc:"shell command run in repository root printing one option per line"`
+	Glob    string `json:"glob,omitempty" yaml:"glob,omitempty" validate:"-" doc:"glob of directories relative to repository root"`
+	File    string `json:"file,omitempty" yaml:"file,omitempty" validate:"-" doc:"file relative to repository root with one option per line"`
+	Match   string `json:"match,omitempty" yaml:"match,omitempty" validate:"-" doc:"regular expression extracting option from each line, its first group if any"`
+
+	match *regexp.Regexp
+}
+
+// Values returns option values read from the source in repository root
+// directory.
+func (s *OptionsSource) Values(root string) ([]string, error) {
+	var (
+		lines []string
+		err   error
+	)
+	switch {
+	case s.Command != "":
+		lines, err = commandLines(s.Command, root)
+	case s.Glob != "":
+		lines, err = globDirs(s.Glob, root)
+	case s.File != "":
+		lines, err = fileLines(filepath.Join(root, s.File))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rgx, err := s.compile()
+	if err != nil {
+		return nil, err
+	}
+
+	var values []string
+	seen := map[string]bool{}
+	for _, line := range lines {
+		for _, value := range extract(strings.TrimSpace(line), rgx) {
+			if value == "" || seen[value] {
+				continue
+			}
+			seen[value] = true
+			values = append(values, value)
+		}
+	}
+	return values, nil
+}
+
+// extract returns all matches of the regular expression in line, or their
+// first groups. Whole line is returned without regular expression.
+func extract(line string, rgx *regexp.Regexp) []string {
+	if rgx == nil {
+		return []string{line}
+	}
+	var values []string
+	for _, m := range rgx.FindAllStringSubmatch(line, -1) {
+		if len(m) > 1 {
+			values = append(values, m[1])
+		} else {
+			values = append(values, m[0])
+		}
+	}
+	return values
+}
+
+func (s *OptionsSource) validate() error {
+	set := 0
+	for _, v := range []string{s.Command, s.Glob, s.File} {
+		if v != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		return ErrInvalidOptionsSource
+	}
+	_, err := s.compile()
+	return err
+}
+
+func (s *OptionsSource) compile() (*regexp.Regexp, error) {
+	if s.Match == "" || s.match != nil {
+		return s.match, nil
+	}
+	rgx, err := regexp.Compile(s.Match)
+	if err != nil {
+		return nil, fmt.Errorf("invalid match pattern: %w", err)
+	}
+	s.match = rgx
+	return rgx, nil
+}
+
+func commandLines(command, dir string) ([]string, error) {
+	cmd := exec.Command("sh", "-c", command)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("run %q: %w: %s", command, err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.Split(string(out), "\n"), nil
+}
+
+func globDirs(pattern, root string) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(root, pattern))
+	if err != nil {
+		return nil, fmt.Errorf("glob %q: %w", pattern, err)
+	}
+	// Hidden directories, like .git, are matched only explicitly.
+	hidden := strings.HasPrefix(filepath.Base(pattern), ".")
+
+	var dirs []string
+	for _, m := range matches {
+		if strings.HasPrefix(filepath.Base(m), ".") && !hidden {
+			continue
+		}
+		if info, err := os.Stat(m); err != nil || !info.IsDir() {
+			continue
+		}
+		rel, err := filepath.Rel(root, m)
+		if err != nil {
+			return nil, err
+		}
+		dirs = append(dirs, filepath.ToSlash(rel))
+	}
+	return dirs, nil
+}
+
+// fileLines returns lines of the file without comments starting with "#".
+func fileLines(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open options file: %w", err)
+	}
+	defer file.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+// ErrInvalidOptionsSource indicates options source without exactly one of
+// command, glob or file.
+var ErrInvalidOptionsSource = errors.New("options_from expects exactly one of command, glob or file")
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestOptionsSource_Values(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{".git", "api", "web", "internal/app", "internal/config"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile(t, filepath.Join(root, "README.md"), "")
+	writeFile(t, filepath.Join(root, ".github", "CODEOWNERS"), `# owners
+/api/ @org/backend @org/infra
+/web/ @org/frontend
+* @org/backend
+`)
+
+	tests := []struct {
+		name   string
+		source OptionsSource
+		want   []string
+	}{
+		{
+			name:   "command",
+			source: OptionsSource{Command: `printf 'b\na\n\nb\n'`},
+			want:   []string{"b", "a"},
+		},
+		{
+			name:   "glob of directories",
+			source: OptionsSource{Glob: "*"},
+			want:   []string{"api", "internal", "web"},
+		},
+		{
+			name:   "nested glob with match",
+			source: OptionsSource{Glob: "internal/*", Match: `[^/]+$`},
+			want:   []string{"app", "config"},
+		},
+		{
+			name:   "file with match group",
+			source: OptionsSource{File: ".github/CODEOWNERS", Match: `@org/([\w-]+)`},
+			want:   []string{"backend", "infra", "frontend"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.source.validate(); err != nil {
+				t.Fatalf("validate() error = %v", err)
+			}
+			got, err := tt.source.Values(root)
+			if err != nil {
+				t.Fatalf("Values() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Values() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := (&OptionsSource{Command: "exit 3"}).Values(root); err == nil {
+		t.Errorf("Values() expected error of failed command")
+	}
+	if err := (&OptionsSource{Glob: "*", File: "x"}).validate(); !errors.Is(err, ErrInvalidOptionsSource) {
+		t.Errorf("validate() error = %v, want %v", err, ErrInvalidOptionsSource)
+	}
+}
+
+func TestConfig_ResolveOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		commands bool
+		want     []string
+		wantRun  bool
+	}{
+		{
+			name:     "command sources",
+			commands: true,
+			want:     []string{"api", "cli"},
+			wantRun:  true,
+		},
+		{
+			name: "without command sources",
+			want: []string{"api"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			cfg := &Config{Args: []Arg{
+				{Name: "Scope", Options: []Option{{Value: "api"}}, OptionsFrom: &OptionsSource{Command: "touch ran && echo cli"}},
+			}}
+			if err := cfg.ResolveOptions(root, tt.commands); err != nil {
+				t.Fatalf("ResolveOptions() error = %v", err)
+			}
+
+			arg := &cfg.Args[0]
+			if got := arg.OptionValues(); !slices.Equal(got, tt.want) {
+				t.Errorf("ResolveOptions() options = %v, want %v", got, tt.want)
+			}
+			if _, err := os.Stat(filepath.Join(root, "ran")); (err == nil) != tt.wantRun {
+				t.Errorf("ResolveOptions() command run = %v, want %v", err == nil, tt.wantRun)
+			}
+			// Values of unresolved source aren't known, so any is accepted.
+			if err := arg.Validate("cli"); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+			if err := arg.Validate("web"); (err != nil) != tt.commands {
+				t.Errorf("Validate() error = %v, want error %v", err, tt.commands)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package fuzzy
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Distance returns Levenshtein edit distance between two strings, counted in
+// characters.
+func Distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// Closest returns candidate with the lowest case-insensitive edit distance to
+// value. False is returned when there is no candidate within maxDistance.
+func Closest(value string, candidates []string, maxDistance int) (string, bool) {
+	value = strings.ToLower(value)
+
+	best, bestDistance := "", maxDistance+1
+	for _, c := range candidates {
+		if d := Distance(value, strings.ToLower(c)); d < bestDistance {
+			best, bestDistance = c, d
+		}
+	}
+	return best, bestDistance <= maxDistance
+}
+
+// Match returns true if all characters of pattern appear in value in the same
+// order, ignoring case, e.g. "ft" matches "feat". Empty pattern matches any
+// value.
+func Match(pattern, value string) bool {
+	value = strings.ToLower(value)
+	for _, r := range strings.ToLower(pattern) {
+		i := strings.IndexRune(value, r)
+		if i < 0 {
+			return false
+		}
+		value = value[i+utf8.RuneLen(r):]
+	}
+	return true
+}
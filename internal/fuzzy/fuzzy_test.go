@@ -0,0 +1,64 @@
+package fuzzy
+
+import (
+	"testing"
+)
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "", b: "", want: 0},
+		{a: "feat", b: "", want: 4},
+		{a: "feat", b: "feat", want: 0},
+		{a: "feet", b: "feat", want: 1},
+		{a: "authn", b: "auth", want: 1},
+		{a: "kitten", b: "sitting", want: 3},
+		{a: "żółw", b: "zolw", want: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"-"+tt.b, func(t *testing.T) {
+			if got := Distance(tt.a, tt.b); got != tt.want {
+				t.Fatalf("Distance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClosest(t *testing.T) {
+	candidates := []string{"feat!", "feat", "fix", "docs"}
+
+	if got, ok := Closest("feet", candidates, 2); !ok || got != "feat" {
+		t.Fatalf("Closest() = %v, %v, want feat", got, ok)
+	}
+	if got, ok := Closest("Docs", candidates, 0); !ok || got != "docs" {
+		t.Fatalf("Closest() = %v, %v, want docs", got, ok)
+	}
+	if got, ok := Closest("refactor", candidates, 2); ok {
+		t.Fatalf("Closest() = %v, want no match", got)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern, value string
+		want           bool
+	}{
+		{pattern: "", value: "feat", want: true},
+		{pattern: "ft", value: "feat", want: true},
+		{pattern: "FEAT", value: "feat!", want: true},
+		{pattern: "ref", value: "refactor", want: true},
+		{pattern: "tf", value: "feat", want: false},
+		{pattern: "docs", value: "doc", want: false},
+		{pattern: "zł", value: "żółw", want: false},
+		{pattern: "żw", value: "Żółw", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+"-"+tt.value, func(t *testing.T) {
+			if got := Match(tt.pattern, tt.value); got != tt.want {
+				t.Fatalf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
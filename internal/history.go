@@ -0,0 +1,185 @@
+package internal
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/kam9lo/gover/internal/config"
+	"github.com/kam9lo/gover/internal/fuzzy"
+	"github.com/kam9lo/gover/internal/prompt"
+	"github.com/kam9lo/gover/internal/report"
+	"github.com/kam9lo/gover/internal/repository"
+)
+
+// parseHistory returns messages of not ignored commits matching commit
+// template of the configuration, newest first. Limit restricts number of read
+// commits, zero reads all, and commits with excluded hashes are skipped
+// without counting towards the limit.
+func parseHistory(
+	repo *repository.Repository, cfg *config.Config, limit int, exclude map[string]bool,
+) ([]repository.Message, error) {
+	commits, err := repo.Log(limit, exclude)
+	if err != nil {
+		return nil, err
+	}
+	parser, err := repository.NewParser(cfg.Templates.Commit)
+	if err != nil {
+		return nil, fmt.Errorf("new parser: %w", err)
+	}
+
+	var messages []repository.Message
+	for _, c := range commits {
+		if cfg.Ignore.Match(c.Message, c.Signature(), c.Merge) {
+			continue
+		}
+		if msg, ok := parser.Match(c.Message); ok {
+			messages = append(messages, msg)
+		}
+	}
+	return messages, nil
+}
+
+type usedValue struct {
+	value string
+	count int
+	// score ranks values by frequency and recency of usage.
+	score float64
+}
+
+// usedValues returns non-empty values of the field ranked by usage in
+// history, newest message first. Each usage scores from 1 for the newest
+// message down to 0.5 for the oldest one, so a value used in recent commits
+// may outrank one used slightly more often in old commits.
+func usedValues(field string, history []repository.Message) []usedValue {
+	index := map[string]int{}
+	var used []usedValue
+	for i, msg := range history {
+		value := msg[field]
+		if value == "" {
+			continue
+		}
+		j, found := index[value]
+		if !found {
+			j = len(used)
+			index[value] = j
+			used = append(used, usedValue{value: value})
+		}
+		used[j].count++
+		used[j].score += 1 - float64(i)/float64(2*len(history))
+	}
+	sort.SliceStable(used, func(i, j int) bool {
+		if used[i].score != used[j].score {
+			return used[i].score > used[j].score
+		}
+		return used[i].value < used[j].value
+	})
+	return used
+}
+
+// isVocabulary returns true if used values are single words repeated across
+// commits, like scopes, as opposed to free text, like messages.
+func isVocabulary(used []usedValue) bool {
+	repeated := false
+	for _, u := range used {
+		if strings.ContainsFunc(u.value, unicode.IsSpace) {
+			return false
+		}
+		repeated = repeated || u.count > 1
+	}
+	return repeated
+}
+
+// knownValues returns values used in recent commits of free-text args by arg
+// name, ranked by frequency and recency. Only args with vocabulary, like
+// scopes, are returned. Commits with excluded hashes are skipped.
+func (a *App) knownValues(exclude map[string]bool) (map[string][]usedValue, error) {
+	if a.cfg.History.Depth == 0 {
+		return nil, nil
+	}
+	history, err := parseHistory(a.repo, a.cfg, a.cfg.History.Depth, exclude)
+	if err != nil {
+		return nil, fmt.Errorf("parse history: %w", err)
+	}
+
+	known := map[string][]usedValue{}
+	for _, arg := range a.cfg.AllArgs() {
+		if len(arg.Options) != 0 || arg.Multiline {
+			continue
+		}
+		if used := usedValues(arg.Name, history); isVocabulary(used) {
+			known[arg.Name] = used
+		}
+	}
+	return known, nil
+}
+
+// suggestions returns values used in history, which are still valid values
+// of the arg, followed by [noneItem] entry when the arg isn't required.
+func suggestions(arg *config.Arg, used []usedValue) []prompt.SelectionItem {
+	var items []prompt.SelectionItem
+	for _, u := range used {
+		if u.value == noneItem || arg.Validate(u.value) != nil {
+			continue
+		}
+		items = append(items, prompt.SelectionItem{
+			Name: u.value,
+			Help: usage(u.count),
+		})
+	}
+	if len(items) != 0 && !arg.Required {
+		items = append(items, prompt.SelectionItem{Name: noneItem, Help: noneItemHelp})
+	}
+	return items
+}
+
+// noneItem is a suggestion entry leaving optional arg empty.
+const (
+	noneItem     = "none"
+	noneItemHelp = "leave empty"
+)
+
+func usage(count int) string {
+	if count == 1 {
+		return "used in 1 commit"
+	}
+	return fmt.Sprintf("used in %d commits", count)
+}
+
+// reviewSimilar adds warnings of values not used in history, which are very
+// close to used ones, e.g. "authn" or "Auth" when "auth" is known.
+func (a *App) reviewSimilar(c *report.Commit, msg repository.Message, known map[string][]usedValue) {
+	for _, arg := range a.cfg.AllArgs() {
+		value, used := msg[arg.Name], known[arg.Name]
+		if value == "" || len(used) == 0 {
+			continue
+		}
+		values := make([]string, 0, len(used))
+		for _, u := range used {
+			values = append(values, u.value)
+		}
+		if slices.Contains(values, value) {
+			continue
+		}
+		similar, ok := fuzzy.Closest(value, values, similarDistance(value))
+		if !ok {
+			continue
+		}
+		c.Add(
+			report.SeverityWarning, ruleSimilar, arg.Name,
+			fmt.Sprintf("%s: new value %q is similar to used %q", arg.Name, value, similar),
+		)
+	}
+}
+
+// similarDistance returns edit distance of values considered similar, which
+// grows with value length.
+func similarDistance(value string) int {
+	return max(1, utf8.RuneCountInString(value)/4)
+}
+
+// ruleSimilar is a verification rule of new values similar to used ones.
+const ruleSimilar = "similar-value"
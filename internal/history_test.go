@@ -0,0 +1,106 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/kam9lo/gover/internal/config"
+	"github.com/kam9lo/gover/internal/report"
+	"github.com/kam9lo/gover/internal/repository"
+)
+
+func TestUsedValues(t *testing.T) {
+	history := []repository.Message{
+		{"Type": "feat", "Scope": "api"},
+		{"Type": "fix", "Scope": "cli"},
+		{"Type": "fix", "Scope": ""},
+		{"Type": "feat"},
+		{"Type": "docs"},
+		{"Type": "fix"},
+		{"Type": "docs"},
+	}
+	got := usedValues("Type", history)
+
+	want := []struct {
+		value string
+		count int
+	}{{"fix", 3}, {"feat", 2}, {"docs", 2}}
+	if len(got) != len(want) {
+		t.Fatalf("usedValues() = %v, want %v", got, want)
+	}
+	for i, w := range want {
+		if got[i].value != w.value || got[i].count != w.count {
+			t.Errorf("usedValues()[%d] = %v, want %v", i, got[i], w)
+		}
+	}
+
+	if isVocabulary(usedValues("Scope", history)) {
+		t.Errorf("isVocabulary() = true for values used once")
+	}
+	if isVocabulary([]usedValue{{value: "add parser", count: 2}}) {
+		t.Errorf("isVocabulary() = true for free text")
+	}
+}
+
+func TestApp_reviewSimilar(t *testing.T) {
+	a := &App{cfg: &config.Config{Args: []config.Arg{
+		{Name: "Scope"},
+		{Name: "Task"},
+	}}}
+	known := map[string][]usedValue{
+		"Scope": {{value: "auth", count: 3}, {value: "api", count: 2}},
+	}
+
+	tests := []struct {
+		name string
+		msg  repository.Message
+		want bool
+	}{
+		{name: "used value", msg: repository.Message{"Scope": "auth"}},
+		{name: "new value", msg: repository.Message{"Scope": "parser"}},
+		{name: "empty value", msg: repository.Message{"Scope": ""}},
+		{name: "similar value", msg: repository.Message{"Scope": "authn"}, want: true},
+		{name: "different case", msg: repository.Message{"Scope": "Auth"}, want: true},
+		{name: "arg without history", msg: repository.Message{"Task": "autn"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &report.Commit{}
+			a.reviewSimilar(c, tt.msg, known)
+			if got := len(c.Violations) != 0; got != tt.want {
+				t.Fatalf("reviewSimilar() violations = %v, want %v", c.Violations, tt.want)
+			}
+			for _, v := range c.Violations {
+				if v.Severity != report.SeverityWarning || v.Rule != ruleSimilar {
+					t.Errorf("reviewSimilar() violation = %+v, want %s warning", v, ruleSimilar)
+				}
+			}
+		})
+	}
+}
+
+func TestApp_knownValues(t *testing.T) {
+	cfg := testConfig + `history:
+  depth: 2
+`
+	app := newTestApp(t, cfg,
+		"fix(auth): handle expired token",
+		"feat(auth): add logout",
+		"fix(api): validate body",
+		"fix(api): handle EOF",
+	)
+	log, err := app.repo.Log(0, nil)
+	if err != nil {
+		t.Fatalf("Log() error = %v", err)
+	}
+	// Verified commits fill the depth, older commits are read instead.
+	exclude := map[string]bool{log[0].Hash: true, log[1].Hash: true}
+
+	known, err := app.knownValues(exclude)
+	if err != nil {
+		t.Fatalf("knownValues() error = %v", err)
+	}
+	used := known["Scope"]
+	if len(used) != 1 || used[0].value != "auth" || used[0].count != 2 {
+		t.Errorf("knownValues() Scope = %+v, want auth used twice", used)
+	}
+}
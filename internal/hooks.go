@@ -0,0 +1,71 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/kam9lo/gover/internal/hooks"
+	"github.com/kam9lo/gover/internal/repository"
+)
+
+// InstallHooks installs git hooks running gover into hooks directory of the
+// repository and prints their status. Existing hooks are chained.
+func InstallHooks(repoPath string) error {
+	dir, shared, err := hooksDir(repoPath)
+	if err != nil {
+		return err
+	}
+	if shared {
+		fmt.Fprintf(os.Stderr,
+			"warning: %s is set in global core.hooksPath and shared by all repositories, hooks skip repositories without gover configuration\n",
+			dir,
+		)
+	}
+	if err := hooks.Install(dir); err != nil {
+		return fmt.Errorf("install hooks: %w", err)
+	}
+	return printHooks(dir)
+}
+
+// UninstallHooks removes git hooks installed by gover, restores hooks they
+// chain and prints status of hooks.
+func UninstallHooks(repoPath string) error {
+	dir, _, err := hooksDir(repoPath)
+	if err != nil {
+		return err
+	}
+	if err := hooks.Uninstall(dir); err != nil {
+		return fmt.Errorf("uninstall hooks: %w", err)
+	}
+	return printHooks(dir)
+}
+
+// HooksStatus prints hooks directory of the repository and whether hooks are
+// installed by gover.
+func HooksStatus(repoPath string) error {
+	dir, _, err := hooksDir(repoPath)
+	if err != nil {
+		return err
+	}
+	return printHooks(dir)
+}
+
+func hooksDir(repoPath string) (string, bool, error) {
+	repo, err := repository.Open(repoPath)
+	if err != nil {
+		return "", false, err
+	}
+	return repo.HooksDir()
+}
+
+func printHooks(dir string) error {
+	statuses, err := hooks.Statuses(dir)
+	if err != nil {
+		return fmt.Errorf("hooks status: %w", err)
+	}
+	fmt.Println(dir)
+	for _, s := range statuses {
+		fmt.Printf("  %s\n", s)
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+// Package hooks installs git hooks running gover: prepare-commit-msg prompts
+// for commit message, commit-msg verifies it and pre-push verifies pushed
+// commits. Hooks existing before installation are renamed with ".local"
+// suffix and run first. Hooks do nothing in repositories without gover
+// configuration, as hooks directory may be shared.
+package hooks
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Names of installed hooks.
+var Names = []string{"prepare-commit-msg", "commit-msg", "pre-push"}
+
+// State of hook in hooks directory.
+type State string
+
+const (
+	// StateMissing is a hook without file.
+	StateMissing State = "not installed"
+	// StateInstalled is a hook installed by gover.
+	StateInstalled State = "installed"
+	// StateOutdated is a hook installed by other version of gover.
+	StateOutdated State = "outdated"
+	// StateForeign is a hook not installed by gover.
+	StateForeign State = "not managed by gover"
+)
+
+// Status of hook in hooks directory.
+type Status struct {
+	Name  string
+	State State
+	// Chained is true when hook existing before installation is kept with
+	// ".local" suffix.
+	Chained bool
+}
+
+func (s Status) String() string {
+	if s.Chained {
+		return fmt.Sprintf("%s: %s, chains %s", s.Name, s.State, s.Name+localSuffix)
+	}
+	return fmt.Sprintf("%s: %s", s.Name, s.State)
+}
+
+// Install writes hooks into the directory. Hooks not installed by gover are
+// renamed with ".local" suffix and chained, hooks installed before are
+// updated.
+func Install(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create hooks directory: %w", err)
+	}
+	for _, name := range Names {
+		if err := install(dir, name); err != nil {
+			return fmt.Errorf("install %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func install(dir, name string) error {
+	path := filepath.Join(dir, name)
+	state, err := hookState(path, name)
+	if err != nil {
+		return err
+	}
+	if state == StateForeign {
+		if _, err := os.Stat(path + localSuffix); err == nil {
+			return fmt.Errorf("%w: %s", ErrChainConflict, path+localSuffix)
+		}
+		if err := os.Rename(path, path+localSuffix); err != nil {
+			return fmt.Errorf("chain existing hook: %w", err)
+		}
+	}
+
+	script, err := scripts.ReadFile(scriptsDir + "/" + name)
+	if err != nil {
+		return fmt.Errorf("read script: %w", err)
+	}
+	if err := os.WriteFile(path, script, 0o755); err != nil {
+		return fmt.Errorf("write hook: %w", err)
+	}
+	// Mode of existing file isn't changed by write.
+	return os.Chmod(path, 0o755)
+}
+
+// Uninstall removes hooks installed by gover from the directory and restores
+// hooks they chain. Other hooks are kept.
+func Uninstall(dir string) error {
+	for _, name := range Names {
+		path := filepath.Join(dir, name)
+		state, err := hookState(path, name)
+		if err != nil {
+			return fmt.Errorf("uninstall %s: %w", name, err)
+		}
+		if state != StateInstalled && state != StateOutdated {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("uninstall %s: %w", name, err)
+		}
+		err = os.Rename(path+localSuffix, path)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("restore chained %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Statuses returns state of each hook in the directory.
+func Statuses(dir string) ([]Status, error) {
+	statuses := make([]Status, 0, len(Names))
+	for _, name := range Names {
+		path := filepath.Join(dir, name)
+		state, err := hookState(path, name)
+		if err != nil {
+			return nil, err
+		}
+		_, err = os.Stat(path + localSuffix)
+		statuses = append(statuses, Status{
+			Name:    name,
+			State:   state,
+			Chained: err == nil && state != StateForeign && state != StateMissing,
+		})
+	}
+	return statuses, nil
+}
+
+// hookState returns state of hook file, which is recognized as installed by
+// gover with marker comment.
+func hookState(path, name string) (State, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return StateMissing, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("read hook: %w", err)
+	}
+	if !bytes.Contains(content, []byte(marker)) {
+		return StateForeign, nil
+	}
+	script, err := scripts.ReadFile(scriptsDir + "/" + name)
+	if err != nil {
+		return "", fmt.Errorf("read script: %w", err)
+	}
+	if !bytes.Equal(content, script) {
+		return StateOutdated, nil
+	}
+	return StateInstalled, nil
+}
+
+//go:embed scripts
+var scripts embed.FS
+
+const (
+	scriptsDir  = "scripts"
+	localSuffix = ".local"
+	// marker is a comment in scripts identifying hooks installed by gover.
+	marker = "# Installed by gover"
+)
+
+// ErrChainConflict indicates existing hook, which can't be chained, as
+// ".local" hook already exists.
+var ErrChainConflict = errors.New("can't chain existing hook, file already exists")
@@ -0,0 +1,167 @@
+package hooks
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const localHook = "#!/bin/sh\necho local\n"
+
+func TestInstall(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing map[string]string
+		want     []Status
+		wantErr  error
+	}{
+		{
+			name: "empty directory",
+			want: []Status{
+				{Name: "prepare-commit-msg", State: StateInstalled},
+				{Name: "commit-msg", State: StateInstalled},
+				{Name: "pre-push", State: StateInstalled},
+			},
+		},
+		{
+			name:     "chains existing hook",
+			existing: map[string]string{"commit-msg": localHook},
+			want: []Status{
+				{Name: "prepare-commit-msg", State: StateInstalled},
+				{Name: "commit-msg", State: StateInstalled, Chained: true},
+				{Name: "pre-push", State: StateInstalled},
+			},
+		},
+		{
+			name:     "updates outdated hook",
+			existing: map[string]string{"pre-push": "#!/bin/sh\n" + marker + "\ngover verify\n"},
+			want: []Status{
+				{Name: "prepare-commit-msg", State: StateInstalled},
+				{Name: "commit-msg", State: StateInstalled},
+				{Name: "pre-push", State: StateInstalled},
+			},
+		},
+		{
+			name: "chained hook conflict",
+			existing: map[string]string{
+				"commit-msg":       localHook,
+				"commit-msg.local": localHook,
+			},
+			wantErr: ErrChainConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "hooks")
+			writeHooks(t, dir, tt.existing)
+
+			err := Install(dir)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Install() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Install() error = %v", err)
+			}
+			// Installation is repeatable without chaining gover hooks.
+			if err := Install(dir); err != nil {
+				t.Fatalf("Install() again error = %v", err)
+			}
+
+			got, err := Statuses(dir)
+			if err != nil {
+				t.Fatalf("Statuses() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Statuses() = %v, want %v", got, tt.want)
+			}
+			for _, name := range Names {
+				info, err := os.Stat(filepath.Join(dir, name))
+				if err != nil {
+					t.Fatalf("stat %s: %v", name, err)
+				}
+				if info.Mode()&0o111 == 0 {
+					t.Errorf("%s mode = %v, want executable", name, info.Mode())
+				}
+			}
+		})
+	}
+}
+
+func TestUninstall(t *testing.T) {
+	dir := t.TempDir()
+	foreign := "#!/bin/sh\necho foreign\n"
+	writeHooks(t, dir, map[string]string{"commit-msg": localHook})
+	if err := Install(dir); err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+	writeHooks(t, dir, map[string]string{"pre-push": foreign})
+
+	if err := Uninstall(dir); err != nil {
+		t.Fatalf("Uninstall() error = %v", err)
+	}
+
+	got, err := Statuses(dir)
+	if err != nil {
+		t.Fatalf("Statuses() error = %v", err)
+	}
+	want := []Status{
+		{Name: "prepare-commit-msg", State: StateMissing},
+		{Name: "commit-msg", State: StateForeign},
+		{Name: "pre-push", State: StateForeign},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Statuses() = %v, want %v", got, want)
+	}
+	for name, content := range map[string]string{"commit-msg": localHook, "pre-push": foreign} {
+		got, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("read %s: %v", name, err)
+		}
+		if string(got) != content {
+			t.Errorf("%s = %q, want %q", name, got, content)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "commit-msg"+localSuffix)); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("chained hook is not restored, stat error = %v", err)
+	}
+}
+
+func TestScripts(t *testing.T) {
+	for _, name := range Names {
+		script, err := scripts.ReadFile(scriptsDir + "/" + name)
+		if err != nil {
+			t.Fatalf("read %s: %v", name, err)
+		}
+		state, err := hookState(writeHook(t, t.TempDir(), name, string(script)), name)
+		if err != nil {
+			t.Fatalf("hookState() error = %v", err)
+		}
+		if state != StateInstalled {
+			t.Errorf("%s state = %q, want %q", name, state, StateInstalled)
+		}
+	}
+}
+
+func writeHooks(t *testing.T, dir string, hooks map[string]string) {
+	t.Helper()
+	for name, content := range hooks {
+		writeHook(t, dir, name, content)
+	}
+}
+
+func writeHook(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("create hooks directory: %v", err)
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o755); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+	return path
+}
@@ -0,0 +1,259 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/kam9lo/gover/internal/config"
+	"github.com/kam9lo/gover/internal/prompt"
+	"github.com/kam9lo/gover/internal/repository"
+	"github.com/kam9lo/gover/internal/version"
+)
+
+// ErrConfigExists indicates that repository is already configured.
+var ErrConfigExists = errors.New("configuration file already exists")
+
+// initConfig is a configuration file generated by Init. It contains only
+// overrides of the extended preset.
+type initConfig struct {
+	Extends string `yaml:"extends"`
+	Tag     struct {
+		Prefix string `yaml:"prefix,omitempty"`
+	} `yaml:"tag,omitempty"`
+	Templates struct {
+		Changelog string `yaml:"changelog,omitempty"`
+	} `yaml:"templates,omitempty"`
+	Args []initArg `yaml:"args,omitempty"`
+}
+
+type initArg struct {
+	Name     string          `yaml:"name"`
+	Required *bool           `yaml:"required,omitempty"`
+	Options  []config.Option `yaml:"options,omitempty"`
+}
+
+// Init asks for preset, tag prefix, required args and changelog sections and
+// writes validated configuration file in repository root. With fromHistory,
+// options are proposed from values used in existing commits.
+func Init(repoPath string, fromHistory bool) error {
+	repo, err := repository.Open(repoPath)
+	if err != nil {
+		return fmt.Errorf("open repository: %w", err)
+	}
+	root, err := repo.Root()
+	if err != nil {
+		return err
+	}
+	if path, found := config.Lookup(root); found {
+		return fmt.Errorf("%w: %s", ErrConfigExists, path)
+	}
+
+	presets := []prompt.SelectionItem{}
+	for _, name := range config.Presets() {
+		presets = append(presets, prompt.SelectionItem{
+			Name: name,
+			Help: config.PresetDescription(name),
+		})
+	}
+	preset, err := prompt.Select("Preset", presets)
+	if err != nil {
+		return err
+	}
+	base, err := config.NewFromBytes([]byte("extends: "+preset), root)
+	if err != nil {
+		return err
+	}
+
+	out := initConfig{Extends: preset}
+
+	tags, err := repo.Tags()
+	if err != nil {
+		return err
+	}
+	out.Tag.Prefix, err = prompt.TextInputDefault("Tag prefix", tagPrefix(tags), nil)
+	if err != nil {
+		return err
+	}
+
+	var history []repository.Message
+	if fromHistory {
+		history, err = parseHistory(repo, base, 0, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := range base.Args {
+		arg := &base.Args[i]
+		override := initArg{Name: arg.Name}
+
+		required, err := prompt.Confirm(fmt.Sprintf("Is %s required", arg.Name), arg.Required)
+		if err != nil {
+			return err
+		}
+		if required != arg.Required {
+			override.Required = &required
+		}
+
+		if fromHistory {
+			options, err := proposeOptions(arg, history)
+			if err != nil {
+				return err
+			}
+			if options != nil {
+				arg.Options = options
+				override.Options = options
+			}
+		}
+
+		if override.Required != nil || override.Options != nil {
+			out.Args = append(out.Args, override)
+		}
+	}
+
+	if sectionArg := changelogArg(base); sectionArg != nil {
+		out.Templates.Changelog, err = changelogTemplate(base, sectionArg)
+		if err != nil {
+			return err
+		}
+	}
+
+	content, err := yaml.Marshal(out)
+	if err != nil {
+		return fmt.Errorf("encode configuration: %w", err)
+	}
+	if _, err := config.NewFromBytes(content, root); err != nil {
+		return fmt.Errorf("validate generated configuration: %w", err)
+	}
+
+	path := filepath.Join(root, config.FileName)
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		return fmt.Errorf("write configuration: %w", err)
+	}
+	fmt.Println(path)
+
+	return nil
+}
+
+// tagPrefix returns the most common prefix of version tags, "v" by default.
+func tagPrefix(tags []string) string {
+	counts := map[string]int{}
+	for _, tag := range tags {
+		if v, err := version.New(tag); err == nil {
+			counts[v.Prefix]++
+		}
+	}
+	prefix, best := "v", 0
+	for p, count := range counts {
+		if count > best || (count == best && p < prefix) {
+			prefix, best = p, count
+		}
+	}
+	return prefix
+}
+
+// proposeOptions asks whether values of the arg used in history should be
+// added to its options. Nil is returned when options are left unchanged.
+func proposeOptions(arg *config.Arg, history []repository.Message) ([]config.Option, error) {
+	used := usedValues(arg.Name, history)
+
+	if len(arg.Options) == 0 {
+		if !isVocabulary(used) {
+			return nil, nil
+		}
+		values := make([]string, 0, len(used))
+		for _, u := range used {
+			values = append(values, u.value)
+		}
+		ok, err := prompt.Confirm(
+			fmt.Sprintf("Limit %s to used values: %s", arg.Name, strings.Join(values, ", ")),
+			false,
+		)
+		if err != nil || !ok {
+			return nil, err
+		}
+		options := make([]config.Option, 0, len(used))
+		for _, u := range used {
+			options = append(options, config.Option{Value: u.value})
+		}
+		return options, nil
+	}
+
+	var added []config.Option
+	for _, u := range used {
+		if arg.HasOption(u.value) {
+			continue
+		}
+		ok, err := prompt.Confirm(
+			fmt.Sprintf("Add %s %q used in %d commits", arg.Name, u.value, u.count),
+			true,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			added = append(added, config.Option{Value: u.value})
+		}
+	}
+	if len(added) == 0 {
+		return nil, nil
+	}
+	return append(append([]config.Option{}, arg.Options...), added...), nil
+}
+
+// changelogArg returns the first arg with options, which groups changelog
+// into sections.
+func changelogArg(cfg *config.Config) *config.Arg {
+	for i := range cfg.Args {
+		if len(cfg.Args[i].Options) > 0 {
+			return &cfg.Args[i]
+		}
+	}
+	return nil
+}
+
+// changelogTemplate asks which options of the arg have changelog section and
+// returns changelog template listing commits of selected options.
+func changelogTemplate(cfg *config.Config, arg *config.Arg) (string, error) {
+	line := "{{$commit.Message}}"
+	if _, ok := cfg.Arg("Scope"); ok {
+		line = "{{if $commit.Scope}}{{$commit.Scope}} - {{end}}" + line
+	}
+
+	var b strings.Builder
+	for _, o := range arg.Options {
+		ok, err := prompt.Confirm(
+			fmt.Sprintf("Add changelog section for %s %s", arg.Name, o.Value),
+			o.Version != "",
+		)
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			continue
+		}
+
+		title := o.Description
+		if title == "" {
+			title = o.Value
+		}
+		first, size := utf8.DecodeRuneInString(title)
+		title = string(unicode.ToUpper(first)) + title[size:]
+		commits := fmt.Sprintf("index . %q %q", arg.Name, o.Value)
+
+		fmt.Fprintf(&b, "{{- if %s -}}\n", commits)
+		fmt.Fprintf(&b, "# %s:\n", title)
+		fmt.Fprintf(&b, "  {{- range $commit := %s}}\n", commits)
+		fmt.Fprintf(&b, "    - %s\n", line)
+		b.WriteString("  {{- end}}\n")
+		b.WriteString("{{ end }}\n")
+	}
+	return b.String(), nil
+}
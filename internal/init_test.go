@@ -0,0 +1,108 @@
+package internal
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/kam9lo/gover/internal/config"
+	"github.com/kam9lo/gover/internal/prompt"
+)
+
+func TestInit(t *testing.T) {
+	history := []string{"[FIX] handle EOF", "[REFACTOR] split parser", "[REFACTOR] rename lexer"}
+	tests := []struct {
+		name        string
+		fromHistory bool
+		answers     []string
+		wantPrefix  string
+		wantArgs    map[string]bool
+		wantOptions []string
+		wantSection []string
+	}{
+		{
+			name: "preset, prefix and required args",
+			answers: []string{
+				"simple", "release-",
+				"yes", "yes", "yes", // Type, Message, Description required
+				"yes", "yes", "yes", "no", "no", // changelog sections
+			},
+			wantPrefix:  "release-",
+			wantArgs:    map[string]bool{"Type": true, "Message": true, "Description": true},
+			wantOptions: []string{"BREAKING", "FEATURE", "FIX", "DOCS", "CHORE"},
+			wantSection: []string{"BREAKING", "FEATURE", "FIX"},
+		},
+		{
+			name:        "options from history",
+			fromHistory: true,
+			answers: []string{
+				"simple", "v",
+				"yes", "yes", // Type required, add REFACTOR
+				"yes", "no", // Message and Description required
+				"yes", "yes", "yes", "no", "no", "yes", // changelog sections
+			},
+			wantPrefix:  "v",
+			wantArgs:    map[string]bool{"Type": true, "Message": true, "Description": false},
+			wantOptions: []string{"BREAKING", "FEATURE", "FIX", "DOCS", "CHORE", "REFACTOR"},
+			wantSection: []string{"BREAKING", "FEATURE", "FIX", "REFACTOR"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := newTestRepository(t, history...)
+			script := prompt.NewScript(tt.answers...)
+			defer prompt.SetDriver(prompt.SetDriver(script))
+
+			if err := Init(root, tt.fromHistory); err != nil {
+				t.Fatalf("Init() error = %v", err)
+			}
+			if len(script.Answers) != 0 {
+				t.Errorf("Init() left answers %q, asked %q", script.Answers, script.Asked)
+			}
+
+			cfg, err := config.NewFromFile(filepath.Join(root, config.FileName))
+			if err != nil {
+				t.Fatalf("NewFromFile() error = %v", err)
+			}
+			if cfg.Tag.Prefix != tt.wantPrefix {
+				t.Errorf("Init() tag prefix = %q, want %q", cfg.Tag.Prefix, tt.wantPrefix)
+			}
+			for name, want := range tt.wantArgs {
+				arg, _ := cfg.Arg(name)
+				if arg == nil || arg.Required != want {
+					t.Errorf("Init() arg %s = %+v, want required %v", name, arg, want)
+				}
+			}
+			typeArg, _ := cfg.Arg("Type")
+			if got := typeArg.OptionValues(); !slices.Equal(got, tt.wantOptions) {
+				t.Errorf("Init() Type options = %v, want %v", got, tt.wantOptions)
+			}
+			for _, o := range tt.wantOptions {
+				section := strings.Contains(cfg.Templates.Changelog, `"`+o+`"`)
+				if want := slices.Contains(tt.wantSection, o); section != want {
+					t.Errorf("Init() changelog section of %s = %v, want %v", o, section, want)
+				}
+			}
+		})
+	}
+}
+
+func TestTagPrefix(t *testing.T) {
+	tests := []struct {
+		name string
+		tags []string
+		want string
+	}{
+		{name: "defaults to v", tags: nil, want: "v"},
+		{name: "no prefix", tags: []string{"1.0.0", "1.1.0"}, want: ""},
+		{name: "most common prefix", tags: []string{"release-1.0.0", "release-1.1.0", "v2.0.0", "latest"}, want: "release-"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tagPrefix(tt.tags); got != tt.want {
+				t.Errorf("tagPrefix() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
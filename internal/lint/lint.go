@@ -0,0 +1,277 @@
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/kam9lo/gover/internal/config"
+	"github.com/kam9lo/gover/internal/report"
+)
+
+// Lint rules identifiers.
+const (
+	RuleSubjectMaxLength  = "subject-max-length"
+	RuleSubjectFullStop   = "subject-full-stop"
+	RuleSubjectImperative = "subject-imperative"
+	RuleBodyLeadingBlank  = "body-leading-blank"
+	RuleBodyMaxLineLength = "body-max-line-length"
+	RuleTypeLowercase     = "type-lowercase"
+	RuleForbiddenWords    = "forbidden-words"
+)
+
+// SeverityOff disables the rule.
+const SeverityOff = "off"
+
+const (
+	defaultMaxLength       = 72
+	defaultTypeField       = "Type"
+	defaultImperativeField = "Message"
+	defaultForbiddenWord   = "WIP"
+)
+
+// Finding is a lint rule violation found in commit message.
+type Finding struct {
+	Rule     string
+	Severity report.Severity
+	Field    string
+	Message  string
+}
+
+// Message is a commit message with parameters parsed from template.
+type Message struct {
+	Text   string
+	Fields map[string]string
+}
+
+func (m Message) subject() string {
+	subject, _, _ := strings.Cut(m.Text, "\n")
+	return subject
+}
+
+func (m Message) lines() []string {
+	return strings.Split(m.Text, "\n")
+}
+
+// Linter checks commit messages against configured rules.
+type Linter struct {
+	rules []*rule
+}
+
+type rule struct {
+	id       string
+	severity string
+	cfg      config.LintRule
+	check    func(r *rule, m Message) []Finding
+}
+
+func (r *rule) finding(field, format string, args ...any) Finding {
+	return Finding{
+		Rule:     r.id,
+		Severity: report.Severity(r.severity),
+		Field:    field,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
+
+// New returns linter with default rules overridden by configuration. Body
+// width is the default maximum length of body lines.
+func New(cfg map[string]config.LintRule, bodyWidth int) (*Linter, error) {
+	if bodyWidth <= 0 {
+		bodyWidth = defaultMaxLength
+	}
+
+	rules := map[string]*rule{
+		RuleSubjectMaxLength: {
+			severity: string(report.SeverityWarning),
+			cfg:      config.LintRule{Max: defaultMaxLength},
+			check:    checkSubjectMaxLength,
+		},
+		RuleSubjectFullStop: {
+			severity: string(report.SeverityWarning),
+			check:    checkSubjectFullStop,
+		},
+		RuleSubjectImperative: {
+			severity: SeverityOff,
+			cfg:      config.LintRule{Field: defaultImperativeField},
+			check:    checkSubjectImperative,
+		},
+		RuleBodyLeadingBlank: {
+			severity: string(report.SeverityWarning),
+			check:    checkBodyLeadingBlank,
+		},
+		RuleBodyMaxLineLength: {
+			severity: string(report.SeverityWarning),
+			cfg:      config.LintRule{Max: bodyWidth},
+			check:    checkBodyMaxLineLength,
+		},
+		RuleTypeLowercase: {
+			severity: string(report.SeverityWarning),
+			cfg:      config.LintRule{Field: defaultTypeField},
+			check:    checkTypeLowercase,
+		},
+		RuleForbiddenWords: {
+			severity: string(report.SeverityWarning),
+			cfg:      config.LintRule{Words: []string{defaultForbiddenWord}},
+			check:    checkForbiddenWords,
+		},
+	}
+
+	for id, override := range cfg {
+		r, ok := rules[id]
+		if !ok {
+			return nil, fmt.Errorf("unknown lint rule: %s", id)
+		}
+		if override.Severity != "" {
+			r.severity = override.Severity
+		}
+		if override.Max != 0 {
+			r.cfg.Max = override.Max
+		}
+		if override.Words != nil {
+			r.cfg.Words = override.Words
+		}
+		if override.Field != "" {
+			r.cfg.Field = override.Field
+		}
+	}
+
+	l := &Linter{}
+	for id, r := range rules {
+		r.id = id
+		if r.severity != SeverityOff {
+			l.rules = append(l.rules, r)
+		}
+	}
+	sort.Slice(l.rules, func(i, j int) bool {
+		return l.rules[i].id < l.rules[j].id
+	})
+	return l, nil
+}
+
+// Lint returns findings of all enabled rules.
+func (l *Linter) Lint(m Message) []Finding {
+	var findings []Finding
+	for _, r := range l.rules {
+		findings = append(findings, r.check(r, m)...)
+	}
+	return findings
+}
+
+func checkSubjectMaxLength(r *rule, m Message) []Finding {
+	if n := utf8.RuneCountInString(m.subject()); n > r.cfg.Max {
+		return []Finding{r.finding("", "subject has %d characters, maximum is %d", n, r.cfg.Max)}
+	}
+	return nil
+}
+
+func checkSubjectFullStop(r *rule, m Message) []Finding {
+	if strings.HasSuffix(m.subject(), ".") {
+		return []Finding{r.finding("", "subject must not end with a period")}
+	}
+	return nil
+}
+
+func checkBodyLeadingBlank(r *rule, m Message) []Finding {
+	lines := m.lines()
+	if len(lines) > 1 && strings.TrimSpace(lines[1]) != "" {
+		return []Finding{r.finding("", "subject must be followed by a blank line")}
+	}
+	return nil
+}
+
+func checkBodyMaxLineLength(r *rule, m Message) []Finding {
+	var findings []Finding
+	for i, line := range m.lines() {
+		// Links can't be wrapped.
+		if i == 0 || strings.Contains(line, "://") {
+			continue
+		}
+		if n := utf8.RuneCountInString(line); n > r.cfg.Max {
+			findings = append(findings, r.finding(
+				"", "body line %d has %d characters, maximum is %d", i+1, n, r.cfg.Max,
+			))
+		}
+	}
+	return findings
+}
+
+func checkTypeLowercase(r *rule, m Message) []Finding {
+	value := m.Fields[r.cfg.Field]
+	if value != strings.ToLower(value) {
+		return []Finding{r.finding(r.cfg.Field, "%s must be lowercase, got %q", r.cfg.Field, value)}
+	}
+	return nil
+}
+
+func checkSubjectImperative(r *rule, m Message) []Finding {
+	text, ok := m.Fields[r.cfg.Field]
+	if !ok {
+		text = m.subject()
+		if _, description, found := strings.Cut(text, ": "); found {
+			text = description
+		}
+	}
+
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return nil
+	}
+	if word := strings.ToLower(words[0]); !isImperative(word) {
+		return []Finding{r.finding(
+			r.cfg.Field,
+			"%q is not in imperative mood, e.g. use \"add\" instead of \"added\" or \"adds\"",
+			words[0],
+		)}
+	}
+	return nil
+}
+
+// isImperative is a heuristic that rejects common past tense, gerund and
+// third person verb forms.
+func isImperative(word string) bool {
+	if imperativeExceptions[word] {
+		return true
+	}
+	switch {
+	case len(word) > 4 && strings.HasSuffix(word, "ed"):
+		return false
+	case len(word) > 5 && strings.HasSuffix(word, "ing"):
+		return false
+	case len(word) > 3 && strings.HasSuffix(word, "s") &&
+		!strings.HasSuffix(word, "ss") &&
+		!strings.HasSuffix(word, "us") &&
+		!strings.HasSuffix(word, "is"):
+		return false
+	}
+	return true
+}
+
+var imperativeExceptions = map[string]bool{
+	"embed":   true,
+	"proceed": true,
+	"shred":   true,
+	"speed":   true,
+	"string":  true,
+	"alias":   true,
+	"bias":    true,
+	"canvas":  true,
+}
+
+func checkForbiddenWords(r *rule, m Message) []Finding {
+	var found []string
+	for _, word := range r.cfg.Words {
+		rgx := regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(word) + `\b`)
+		if rgx.MatchString(m.Text) {
+			found = append(found, word)
+		}
+	}
+	if len(found) != 0 {
+		return []Finding{r.finding(
+			"", "message contains forbidden words: %s", strings.Join(found, ", "),
+		)}
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package lint
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kam9lo/gover/internal/config"
+	"github.com/kam9lo/gover/internal/report"
+)
+
+func TestLinter_Lint(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       map[string]config.LintRule
+		msg       Message
+		wantRules []string
+	}{
+		{
+			name: "valid message",
+			msg: Message{
+				Text:   "feat(api): add endpoint\n\ndescription",
+				Fields: map[string]string{"Type": "feat", "Message": "add endpoint"},
+			},
+		},
+		{
+			name: "default rules",
+			msg: Message{
+				Text:   "Feat(api): WIP add endpoint with a subject line that is definitely too long.\nbody",
+				Fields: map[string]string{"Type": "Feat"},
+			},
+			wantRules: []string{
+				RuleBodyLeadingBlank,
+				RuleForbiddenWords,
+				RuleSubjectFullStop,
+				RuleSubjectMaxLength,
+				RuleTypeLowercase,
+			},
+		},
+		{
+			name: "configured rules",
+			cfg: map[string]config.LintRule{
+				RuleSubjectImperative: {Severity: "error"},
+				RuleBodyMaxLineLength: {Max: 10},
+				RuleForbiddenWords:    {Severity: "off"},
+			},
+			msg: Message{
+				Text:   "fix: fixed WIP\n\nlong body line\nhttps://example.com/link",
+				Fields: map[string]string{"Message": "fixed WIP"},
+			},
+			wantRules: []string{
+				RuleBodyMaxLineLength,
+				RuleSubjectImperative,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, err := New(tt.cfg, 0)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			var gotRules []string
+			for _, f := range l.Lint(tt.msg) {
+				gotRules = append(gotRules, f.Rule)
+			}
+			if !reflect.DeepEqual(gotRules, tt.wantRules) {
+				t.Fatalf("Linter.Lint() rules = %v, want %v", gotRules, tt.wantRules)
+			}
+		})
+	}
+}
+
+func TestLinter_Severity(t *testing.T) {
+	l, err := New(map[string]config.LintRule{RuleSubjectFullStop: {Severity: "error"}}, 0)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	findings := l.Lint(Message{Text: "fix: close connection."})
+	if len(findings) != 1 || findings[0].Severity != report.SeverityError {
+		t.Fatalf("Linter.Lint() = %+v, want single error", findings)
+	}
+
+	if _, err := New(map[string]config.LintRule{"unknown": {}}, 0); err == nil {
+		t.Fatalf("New() error = nil, want unknown rule error")
+	}
+}
+
+func TestIsImperative(t *testing.T) {
+	for word, want := range map[string]bool{
+		"add":     true,
+		"added":   false,
+		"adds":    false,
+		"adding":  false,
+		"embed":   true,
+		"process": true,
+		"focus":   true,
+		"string":  true,
+		"fix":     true,
+		"fixes":   false,
+	} {
+		if got := isImperative(word); got != want {
+			t.Errorf("isImperative(%q) = %v, want %v", word, got, want)
+		}
+	}
+}
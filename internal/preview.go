@@ -0,0 +1,46 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kam9lo/gover/internal/prompt"
+	"github.com/kam9lo/gover/internal/report"
+)
+
+// Actions offered after commit message preview.
+const (
+	actionConfirm = "confirm"
+	actionEdit    = "edit"
+	actionEditor  = "editor"
+	actionAbort   = "abort"
+)
+
+// previewActions returns actions offered after preview. Message with errors
+// can't be confirmed and fields of not editable one can't be changed.
+func previewActions(failed, editable bool) []prompt.SelectionItem {
+	var items []prompt.SelectionItem
+	if !failed {
+		items = append(items, prompt.SelectionItem{Name: actionConfirm, Help: "use the message"})
+	}
+	if editable {
+		items = append(items, prompt.SelectionItem{Name: actionEdit, Help: "change value of a field"})
+	}
+	return append(items,
+		prompt.SelectionItem{Name: actionEditor, Help: "edit the message in $VISUAL or $EDITOR"},
+		prompt.SelectionItem{Name: actionAbort, Help: "discard the message"},
+	)
+}
+
+// printPreview prints commit message followed by its violations to stderr,
+// so stdout is left for the confirmed message.
+func printPreview(message string, violations []report.Violation) {
+	rule := strings.Repeat("─", previewWidth)
+	fmt.Fprintf(os.Stderr, "%s\n%s\n%s\n", rule, strings.TrimRight(message, "\n"), rule)
+	for _, v := range violations {
+		fmt.Fprintf(os.Stderr, "%s [%s] %s\n", v.Severity, v.Rule, v.Message)
+	}
+}
+
+const previewWidth = 40
@@ -0,0 +1,123 @@
+package prompt
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Lines is a driver of line-based prompts for terminals rendering interactive
+// prompts badly, e.g. dumb terminals or Emacs shells. Items are numbered and
+// selected by number or name. Values are edited in external editor when
+// $VISUAL or $EDITOR is set, otherwise read until a line with single ".",
+// replacing the value unless no lines are entered.
+type Lines struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// NewLines returns driver reading answers from in and writing prompts to out.
+func NewLines(in io.Reader, out io.Writer) *Lines {
+	return &Lines{in: bufio.NewReader(in), out: out}
+}
+
+func (l *Lines) Choose(c Choice) (int, error) {
+	fmt.Fprintf(l.out, "%s:\n", c.Label)
+	for i, item := range c.Items {
+		fmt.Fprintf(l.out, "  %d) %s", i+1, item.Name)
+		if item.Help != "" {
+			fmt.Fprintf(l.out, " (%s)", item.Help)
+		}
+		fmt.Fprintln(l.out)
+	}
+
+	for {
+		fmt.Fprintf(l.out, "%s [%d]: ", c.Label, c.Cursor+1)
+		answer, err := l.readLine()
+		if err != nil {
+			return 0, err
+		}
+		if answer == "" {
+			return c.Cursor, nil
+		}
+		if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(c.Items) {
+			return n - 1, nil
+		}
+		for i, item := range c.Items {
+			if item.Name == answer {
+				return i, nil
+			}
+		}
+		fmt.Fprintf(l.out, "%q is not one of items, enter number from 1 to %d\n", answer, len(c.Items))
+	}
+}
+
+func (l *Lines) Input(label, defaultValue string, validate func(string) error) (string, error) {
+	for {
+		if defaultValue != "" {
+			fmt.Fprintf(l.out, "%s [%s]: ", label, defaultValue)
+		} else {
+			fmt.Fprintf(l.out, "%s: ", label)
+		}
+		answer, err := l.readLine()
+		if err != nil {
+			return "", err
+		}
+		if answer == "" {
+			answer = defaultValue
+		}
+		if validate == nil {
+			return answer, nil
+		}
+		verr := validate(answer)
+		if verr == nil {
+			return answer, nil
+		}
+		fmt.Fprintln(l.out, verr)
+	}
+}
+
+func (l *Lines) Edit(label, value string) (string, error) {
+	if configuredEditor() != "" {
+		return editFile(label, value)
+	}
+
+	if value != "" {
+		fmt.Fprintf(l.out, "%s:\n%s\n", label, value)
+	}
+	if value != "" {
+		fmt.Fprintf(l.out, "Enter %s, end with a line containing only %q, enter only %q to keep it:\n", label, linesEnd, linesEnd)
+	} else {
+		fmt.Fprintf(l.out, "Enter %s, end with a line containing only %q:\n", label, linesEnd)
+	}
+	var lines []string
+	for {
+		line, err := l.readLine()
+		if err != nil {
+			return "", err
+		}
+		if line == linesEnd && len(lines) == 0 {
+			return value, nil
+		}
+		if line == linesEnd {
+			return strings.Trim(strings.Join(lines, "\n"), "\n"), nil
+		}
+		lines = append(lines, strings.TrimRight(line, " \t"))
+	}
+}
+
+func (l *Lines) readLine() (string, error) {
+	line, err := l.in.ReadString('\n')
+	if errors.Is(err, io.EOF) && line != "" {
+		err = nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("read answer: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+const linesEnd = "."
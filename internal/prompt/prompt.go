@@ -3,11 +3,40 @@ package prompt
 import (
 	"errors"
 	"fmt"
-	"strings"
-
-	"github.com/manifoldco/promptui"
 )
 
+// Driver displays prompts and returns answers. [Terminal] is the default
+// driver, [Lines] works in terminals without cursor movement and [Script]
+// replays answers in tests.
+type Driver interface {
+	// Choose returns index of the selected item.
+	Choose(c Choice) (int, error)
+	// Input returns entered text, pre-filled with default value. Validate
+	// checks the value, if set.
+	Input(label, defaultValue string, validate func(string) error) (string, error)
+	// Edit returns the value edited as a whole, e.g. in external editor.
+	Edit(label, value string) (string, error)
+}
+
+// Choice is a question answered by selecting one of items.
+type Choice struct {
+	Label string
+	Items []SelectionItem
+	// Cursor is index of initially selected item.
+	Cursor int
+	// Custom is true when the last item is [CustomItem] entry.
+	Custom bool
+}
+
+var driver Driver = Terminal{}
+
+// SetDriver replaces driver of prompts and returns the previous one.
+func SetDriver(d Driver) Driver {
+	prev := driver
+	driver = d
+	return prev
+}
+
 // Selection implements selectable prompt item.
 type Selection interface {
 	Field() string
@@ -19,87 +48,115 @@ type SelectionItem struct {
 	Help string
 }
 
-const (
-	SelectTemplateItemActive   = `{{ "✔" | green | bold }} {{ .Name | cyan | bold }}` + SelectTemplateItemHelp
-	SelectTemplateItemInactive = `  {{ .Name }}` + SelectTemplateItemHelp
-	SelectTemplateItemHelp     = `{{ printf " (%s)" .Help | faint }}`
-)
+func (i SelectionItem) Field() string {
+	return i.Name
+}
+
+func (i SelectionItem) Doc() string {
+	return i.Help
+}
 
 // Select displays prompt with selection. Name is the prefix displayed before
 // selected item and options contains bulk of items to select from.
 func Select[T Selection](name string, options []T) (string, error) {
-	selectionItems := make([]SelectionItem, 0, len(options))
-	for _, o := range options {
-		selectionItems = append(selectionItems, SelectionItem{
-			Name: o.Field(),
-			Help: o.Doc(),
-		})
-	}
-
-	prompt := promptui.Select{
-		Label: name,
-		Items: selectionItems,
-		Templates: &promptui.SelectTemplates{
-			Active:   SelectTemplateItemActive,
-			Inactive: SelectTemplateItemInactive,
-			Selected: SelectTemplateItemActive,
-		},
+	items := selectionItems(options)
+	idx, err := driver.Choose(Choice{Label: name, Items: items})
+	if err != nil {
+		return "", err
 	}
+	return items[idx].Name, nil
+}
 
-	idx, _, err := prompt.Run()
+// SelectCustom displays prompt with selection followed by [CustomItem] entry,
+// which asks for a value not listed in options with text input checked by
+// validate.
+func SelectCustom[T Selection](name string, options []T, validate func(string) error) (string, error) {
+	items := append(selectionItems(options), SelectionItem{Name: CustomItem, Help: customItemHelp})
+	idx, err := driver.Choose(Choice{Label: name, Items: items, Custom: true})
 	if err != nil {
-		return "", fmt.Errorf("prompt run: %w", err)
+		return "", err
 	}
-
-	return selectionItems[idx].Name, err
+	if idx == len(options) {
+		return TextInput(name, validate)
+	}
+	return items[idx].Name, nil
 }
 
-// TextInput displays prompt with simple text input where user provides any
-// non-formatted text. Name is the prefix displayed before text input field.
-func TextInput(name string, required bool) (string, error) {
-	prompt := promptui.Prompt{
-		Label: name,
-		Validate: func(s string) error {
-			if required && s == "" {
-				return errors.New("required")
-			}
-			return nil
-		},
-	}
-	result, err := prompt.Run()
-	if err != nil {
-		return "", fmt.Errorf("prompt run: %w", err)
+func selectionItems[T Selection](options []T) []SelectionItem {
+	items := make([]SelectionItem, 0, len(options)+1)
+	for _, o := range options {
+		items = append(items, SelectionItem{
+			Name: o.Field(),
+			Help: o.Doc(),
+		})
 	}
+	return items
+}
 
-	return wrapString(result, defaultTextInputWidth), nil
+// TextInput displays prompt with simple text input where user provides any
+// non-formatted text. Name is the prefix displayed before text input field and
+// validate checks the value while typing.
+func TextInput(name string, validate func(string) error) (string, error) {
+	return TextInputDefault(name, "", validate)
 }
 
-func wrapString(input string, width int) string {
-	if len(input) <= width {
-		return input
-	}
+// TextInputDefault displays text input prompt pre-filled with default value.
+func TextInputDefault(name, defaultValue string, validate func(string) error) (string, error) {
+	return driver.Input(name, defaultValue, validate)
+}
 
-	var result strings.Builder
-	for len(input) > width {
-		splitIndex := width
-		for splitIndex > 0 && input[splitIndex] != ' ' {
-			splitIndex--
+// Editor returns the value edited as a whole, by default in $VISUAL or
+// $EDITOR. When validate fails, the error is displayed and the value is
+// edited again if user confirms.
+func Editor(name, value string, validate func(string) error) (string, error) {
+	for {
+		result, err := driver.Edit(name, value)
+		if err != nil {
+			return "", err
 		}
-
-		if splitIndex == 0 {
-			splitIndex = width
+		if validate == nil {
+			return result, nil
+		}
+		verr := validate(result)
+		if verr == nil {
+			return result, nil
 		}
 
-		result.WriteString(input[:splitIndex] + "\n")
-
-		input = strings.TrimSpace(input[splitIndex:])
+		fmt.Fprintln(output, verr)
+		again, err := Confirm(fmt.Sprintf("Edit %s again", name), true)
+		if err != nil {
+			return "", err
+		}
+		if !again {
+			return "", verr
+		}
+		value = result
 	}
+}
 
-	result.WriteString(input)
+// Confirm displays yes or no selection with cursor on default answer.
+func Confirm(name string, defaultYes bool) (bool, error) {
+	items := []SelectionItem{{Name: confirmYes}, {Name: confirmNo}}
+	cursor := 1
+	if defaultYes {
+		cursor = 0
+	}
 
-	return result.String()
+	idx, err := driver.Choose(Choice{Label: name, Items: items, Cursor: cursor})
+	if err != nil {
+		return false, err
+	}
+	return items[idx].Name == confirmYes, nil
 }
 
+// CustomItem is a selection entry asking for value not listed in options.
+const CustomItem = "other…"
+
 const (
-	defaultTextInputWidth = 72
+	customItemHelp = "enter value not listed"
+	confirmYes     = "yes"
+	confirmNo      = "no"
 )
+
+// ErrInvalidAnswer indicates answer, which doesn't match the question.
+var ErrInvalidAnswer = errors.New("invalid answer")
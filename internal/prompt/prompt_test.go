@@ -0,0 +1,92 @@
+package prompt
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLines(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "")
+
+	in := strings.Join([]string{
+		"",         // default item
+		"3",        // item by number
+		"fix",      // item by name
+		"refactor", // unknown item, asked again
+		"2",        // item by number
+		"",         // default input
+		"ab",       // invalid input, asked again
+		"abc",      // valid input
+		"first",    // edited value
+		"",
+		"- second",
+		".", // end of edited value
+		".", // kept value
+		"leftover",
+	}, "\n")
+	defer SetDriver(SetDriver(NewLines(strings.NewReader(in), io.Discard)))
+
+	options := []SelectionItem{{Name: "feat"}, {Name: "fix"}, {Name: "docs"}}
+	for _, want := range []string{"feat", "docs", "fix", "fix"} {
+		if got, err := Select("Type", options); err != nil || got != want {
+			t.Fatalf("Select() = %q, %v, want %q", got, err, want)
+		}
+	}
+
+	if got, err := TextInputDefault("Scope", "api", nil); err != nil || got != "api" {
+		t.Fatalf("TextInputDefault() = %q, %v, want api", got, err)
+	}
+	minLength := func(s string) error {
+		if len(s) < 3 {
+			return errors.New("too short")
+		}
+		return nil
+	}
+	if got, err := TextInput("Message", minLength); err != nil || got != "abc" {
+		t.Fatalf("TextInput() = %q, %v, want abc", got, err)
+	}
+
+	if got, err := Editor("Description", "", nil); err != nil || got != "first\n\n- second" {
+		t.Fatalf("Editor() = %q, %v", got, err)
+	}
+	if got, err := Editor("Description", "kept", nil); err != nil || got != "kept" {
+		t.Fatalf("Editor() = %q, %v, want kept", got, err)
+	}
+}
+
+func TestScript(t *testing.T) {
+	script := NewScript("other…", "perf", "feat", "yes", "unknown")
+	defer SetDriver(SetDriver(script))
+
+	options := []SelectionItem{{Name: "feat"}, {Name: "fix"}}
+	if got, err := SelectCustom("Type", options, nil); err != nil || got != "perf" {
+		t.Fatalf("SelectCustom() = %q, %v, want perf", got, err)
+	}
+	if got, err := SelectCustom("Type", options, nil); err != nil || got != "feat" {
+		t.Fatalf("SelectCustom() = %q, %v, want feat", got, err)
+	}
+	if got, err := Confirm("Continue", false); err != nil || !got {
+		t.Fatalf("Confirm() = %v, %v, want true", got, err)
+	}
+	if _, err := Select("Type", options); !errors.Is(err, ErrInvalidAnswer) {
+		t.Fatalf("Select() error = %v, want %v", err, ErrInvalidAnswer)
+	}
+	if _, err := TextInput("Message", nil); !errors.Is(err, ErrScriptEnded) {
+		t.Fatalf("TextInput() error = %v, want %v", err, ErrScriptEnded)
+	}
+
+	want := []string{"Type", "Type", "Type", "Continue", "Type", "Message"}
+	if strings.Join(script.Asked, ",") != strings.Join(want, ",") {
+		t.Errorf("Script.Asked = %v, want %v", script.Asked, want)
+	}
+}
+
+func TestStripComments(t *testing.T) {
+	text := "\nfirst line  \n# comment\n\nsecond line\n\n# Enter value.\n"
+	if got, want := stripComments(text), "first line\n\nsecond line"; got != want {
+		t.Fatalf("stripComments() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,68 @@
+package prompt
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Script is a driver replaying answers, e.g. in tests. Choices are answered
+// with item names, inputs and edits with values.
+type Script struct {
+	Answers []string
+	// Asked are labels of displayed prompts.
+	Asked []string
+}
+
+// NewScript returns driver replaying answers in order.
+func NewScript(answers ...string) *Script {
+	return &Script{Answers: answers}
+}
+
+func (s *Script) Choose(c Choice) (int, error) {
+	answer, err := s.next(c.Label)
+	if err != nil {
+		return 0, err
+	}
+	names := make([]string, 0, len(c.Items))
+	for i, item := range c.Items {
+		if item.Name == answer {
+			return i, nil
+		}
+		names = append(names, item.Name)
+	}
+	return 0, fmt.Errorf(
+		"%w %q of %s, expected one of: %s",
+		ErrInvalidAnswer, answer, c.Label, strings.Join(names, ", "),
+	)
+}
+
+func (s *Script) Input(label, _ string, validate func(string) error) (string, error) {
+	answer, err := s.next(label)
+	if err != nil {
+		return "", err
+	}
+	if validate != nil {
+		if err := validate(answer); err != nil {
+			return "", fmt.Errorf("%w %q of %s: %w", ErrInvalidAnswer, answer, label, err)
+		}
+	}
+	return answer, nil
+}
+
+func (s *Script) Edit(label, _ string) (string, error) {
+	return s.next(label)
+}
+
+func (s *Script) next(label string) (string, error) {
+	s.Asked = append(s.Asked, label)
+	if len(s.Answers) == 0 {
+		return "", fmt.Errorf("%w: %s", ErrScriptEnded, label)
+	}
+	answer := s.Answers[0]
+	s.Answers = s.Answers[1:]
+	return answer, nil
+}
+
+// ErrScriptEnded indicates prompt displayed after all answers were replayed.
+var ErrScriptEnded = errors.New("no answer left in script")
@@ -0,0 +1,158 @@
+package prompt
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/manifoldco/promptui"
+	"github.com/manifoldco/promptui/list"
+
+	"github.com/kam9lo/gover/internal/fuzzy"
+)
+
+// output is a terminal output of prompts. Stdout is left for results, so
+// they can be piped.
+var output = os.Stderr
+
+const (
+	SelectTemplateItemActive   = `{{ "✔" | green | bold }} {{ .Name | cyan | bold }}` + SelectTemplateItemHelp
+	SelectTemplateItemInactive = `  {{ .Name }}` + SelectTemplateItemHelp
+	SelectTemplateItemHelp     = `{{ if .Help }}{{ printf " (%s)" .Help | faint }}{{ end }}`
+)
+
+// Terminal is a driver of interactive prompts with cursor navigation. Items
+// are fuzzy searched by name and help after pressing "/", long lists start in
+// search mode. Values are edited in external editor.
+type Terminal struct{}
+
+func (Terminal) Choose(c Choice) (int, error) {
+	prompt := promptui.Select{
+		Label:     c.Label,
+		Items:     c.Items,
+		CursorPos: c.Cursor,
+		Size:      selectSize,
+		Stdout:    output,
+		Templates: &promptui.SelectTemplates{
+			Active:   SelectTemplateItemActive,
+			Inactive: SelectTemplateItemInactive,
+			Selected: SelectTemplateItemActive,
+		},
+		Searcher:          searcher(c.Items, c.Custom),
+		StartInSearchMode: len(c.Items) > selectSize,
+	}
+
+	idx, _, err := prompt.Run()
+	if err != nil {
+		return 0, fmt.Errorf("prompt run: %w", err)
+	}
+	return idx, nil
+}
+
+// searcher returns promptui searcher fuzzy matching input with item name, or
+// finding it in item help. The custom entry is always listed, so custom value
+// can be entered when nothing matches.
+func searcher(items []SelectionItem, custom bool) list.Searcher {
+	return func(input string, index int) bool {
+		if custom && index == len(items)-1 {
+			return true
+		}
+		item := items[index]
+		return fuzzy.Match(strings.ReplaceAll(input, " ", ""), item.Name) ||
+			strings.Contains(strings.ToLower(item.Help), strings.ToLower(input))
+	}
+}
+
+func (Terminal) Input(label, defaultValue string, validate func(string) error) (string, error) {
+	prompt := promptui.Prompt{
+		Label:     label,
+		Default:   defaultValue,
+		AllowEdit: defaultValue != "",
+		Validate:  validate,
+		Stdout:    output,
+	}
+	result, err := prompt.Run()
+	if err != nil {
+		return "", fmt.Errorf("prompt run: %w", err)
+	}
+	return result, nil
+}
+
+func (Terminal) Edit(label, value string) (string, error) {
+	return editFile(label, value)
+}
+
+// editFile opens $VISUAL or $EDITOR, vi by default, on temporary file with
+// the value and returns edited text without comment lines starting with "#".
+func editFile(label, value string) (string, error) {
+	file, err := os.CreateTemp("", "gover-*.txt")
+	if err != nil {
+		return "", fmt.Errorf("create temporary file: %w", err)
+	}
+	defer os.Remove(file.Name())
+
+	if value != "" {
+		value += "\n"
+	}
+	_, err = fmt.Fprintf(file,
+		"%s\n%s Enter %s. Lines starting with %q are ignored.\n",
+		value, editorComment, label, editorComment,
+	)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", fmt.Errorf("write temporary file: %w", err)
+	}
+
+	editor := editorCommand()
+	// Editor may contain arguments, e.g. "code --wait".
+	cmd := exec.Command("sh", "-c", editor+` "$1"`, "sh", file.Name())
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, output, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("run editor %q: %w", editor, err)
+	}
+
+	content, err := os.ReadFile(file.Name())
+	if err != nil {
+		return "", fmt.Errorf("read temporary file: %w", err)
+	}
+	return stripComments(string(content)), nil
+}
+
+func editorCommand() string {
+	if editor := configuredEditor(); editor != "" {
+		return editor
+	}
+	return defaultEditor
+}
+
+// configuredEditor returns $VISUAL or $EDITOR, empty if none is set.
+func configuredEditor() string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if editor := os.Getenv(env); editor != "" {
+			return editor
+		}
+	}
+	return ""
+}
+
+// stripComments returns text without comment lines, trailing spaces and
+// surrounding blank lines.
+func stripComments(text string) string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(line, editorComment) {
+			continue
+		}
+		lines = append(lines, strings.TrimRight(line, " \t\r"))
+	}
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
+
+const (
+	defaultEditor = "vi"
+	editorComment = "#"
+	selectSize    = 7
+)
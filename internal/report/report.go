@@ -0,0 +1,241 @@
+package report
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Severity of the violation. Only errors fail the verification.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Supported report formats.
+const (
+	FormatText  = "text"
+	FormatJSON  = "json"
+	FormatJUnit = "junit"
+)
+
+// Violation is a single broken rule found in commit message.
+type Violation struct {
+	Commit   string   `json:"commit"`
+	Author   string   `json:"author"`
+	Subject  string   `json:"subject"`
+	Rule     string   `json:"rule"`
+	Field    string   `json:"field,omitempty"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+// Commit is a verified commit with its violations.
+type Commit struct {
+	Hash       string
+	Author     string
+	Subject    string
+	Violations []Violation
+}
+
+// Report is a result of commit messages verification.
+type Report struct {
+	Commits []*Commit
+}
+
+// AddCommit adds verified commit to the report and returns it, so violations
+// can be added.
+func (r *Report) AddCommit(hash, author, subject string) *Commit {
+	c := &Commit{Hash: hash, Author: author, Subject: subject}
+	r.Commits = append(r.Commits, c)
+	return c
+}
+
+// Add adds violation of given rule to the commit.
+func (c *Commit) Add(severity Severity, rule, field, message string) {
+	c.Violations = append(c.Violations, Violation{
+		Commit:   c.Hash,
+		Author:   c.Author,
+		Subject:  c.Subject,
+		Rule:     rule,
+		Field:    field,
+		Severity: severity,
+		Message:  message,
+	})
+}
+
+// Failed returns true if commit has any error violation.
+func (c *Commit) Failed() bool {
+	for _, v := range c.Violations {
+		if v.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Failed returns true if any of the commits has error violation.
+func (r *Report) Failed() bool {
+	return r.failedCommits() != 0
+}
+
+// Violations returns violations of all commits.
+func (r *Report) Violations() []Violation {
+	violations := []Violation{}
+	for _, c := range r.Commits {
+		violations = append(violations, c.Violations...)
+	}
+	return violations
+}
+
+func (r *Report) failedCommits() (n int) {
+	for _, c := range r.Commits {
+		if c.Failed() {
+			n++
+		}
+	}
+	return
+}
+
+// Write writes report in given format.
+func (r *Report) Write(w io.Writer, format string) error {
+	switch format {
+	case FormatText, "":
+		return r.writeText(w)
+	case FormatJSON:
+		return r.writeJSON(w)
+	case FormatJUnit:
+		return r.writeJUnit(w)
+	default:
+		return fmt.Errorf(
+			"unsupported report format: %s\nexpected: %s, %s, %s",
+			format, FormatText, FormatJSON, FormatJUnit,
+		)
+	}
+}
+
+func (r *Report) writeText(w io.Writer) error {
+	var b strings.Builder
+	for _, c := range r.Commits {
+		if len(c.Violations) == 0 {
+			continue
+		}
+		b.WriteString(c.title())
+		if c.Author != "" {
+			fmt.Fprintf(&b, " (%s)", c.Author)
+		}
+		b.WriteString("\n")
+		for _, v := range c.Violations {
+			fmt.Fprintf(&b, "  %s [%s] %s\n", v.Severity, v.Rule, v.Message)
+		}
+	}
+	fmt.Fprintf(&b, "%d of %d commits failed verification\n", r.failedCommits(), len(r.Commits))
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+type jsonReport struct {
+	Commits    int         `json:"commits"`
+	Failed     int         `json:"failed"`
+	Violations []Violation `json:"violations"`
+}
+
+func (r *Report) writeJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(jsonReport{
+		Commits:    len(r.Commits),
+		Failed:     r.failedCommits(),
+		Violations: r.Violations(),
+	})
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Failures  []junitResult `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitResult struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func (r *Report) writeJUnit(w io.Writer) error {
+	suite := junitTestSuite{
+		Name:     "gover verify",
+		Tests:    len(r.Commits),
+		Failures: r.failedCommits(),
+	}
+	for _, c := range r.Commits {
+		tc := junitTestCase{
+			Name:      c.title(),
+			Classname: "commits",
+		}
+		var warnings []string
+		for _, v := range c.Violations {
+			if v.Severity != SeverityError {
+				warnings = append(warnings, fmt.Sprintf("%s [%s] %s", v.Severity, v.Rule, v.Message))
+				continue
+			}
+			tc.Failures = append(tc.Failures, junitResult{
+				Message: v.Message,
+				Type:    v.Rule,
+				Text: fmt.Sprintf(
+					"commit: %s\nauthor: %s\nfield: %s\n%s",
+					c.Hash, c.Author, v.Field, v.Message,
+				),
+			})
+		}
+		tc.SystemOut = strings.Join(warnings, "\n")
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(junitTestSuites{Suites: []junitTestSuite{suite}}); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// title returns short commit hash followed by the subject. Message which is
+// not committed yet has no hash.
+func (c *Commit) title() string {
+	if c.Hash == "" {
+		return c.Subject
+	}
+	return shortHash(c.Hash) + " " + c.Subject
+}
+
+func shortHash(hash string) string {
+	if len(hash) > shortHashLength {
+		return hash[:shortHashLength]
+	}
+	return hash
+}
+
+const shortHashLength = 7
@@ -0,0 +1,90 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func newTestReport() *Report {
+	r := &Report{}
+	r.AddCommit("0123456789abcdef", "Jane", "feat(api): add endpoint")
+	c := r.AddCommit("fedcba9876543210", "John", "feet(api): x")
+	c.Add(SeverityError, "enum-from-options", "Type", "Type: must be one of: feat, fix")
+	c.Add(SeverityWarning, "subject-max-length", "", "subject is too long")
+	return r
+}
+
+func TestReport_Failed(t *testing.T) {
+	r := &Report{}
+	c := r.AddCommit("0123456789abcdef", "Jane", "feat(api): add endpoint")
+	c.Add(SeverityWarning, "subject-max-length", "", "subject is too long")
+	if r.Failed() {
+		t.Fatalf("Report.Failed() = true, want false for warnings only")
+	}
+	if !newTestReport().Failed() {
+		t.Fatalf("Report.Failed() = false, want true")
+	}
+}
+
+func TestReport_Write(t *testing.T) {
+	tests := []struct {
+		format  string
+		want    []string
+		wantErr bool
+	}{
+		{
+			format: FormatText,
+			want: []string{
+				"fedcba9 feet(api): x (John)\n",
+				"  error [enum-from-options] Type: must be one of: feat, fix\n",
+				"1 of 2 commits failed verification\n",
+			},
+		},
+		{
+			format: FormatJUnit,
+			want: []string{
+				`<testsuite name="gover verify" tests="2" failures="1">`,
+				`<testcase name="0123456 feat(api): add endpoint" classname="commits"></testcase>`,
+				`<failure message="Type: must be one of: feat, fix" type="enum-from-options">`,
+			},
+		},
+		{
+			format:  "yaml",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			buff := bytes.NewBuffer(nil)
+			err := newTestReport().Write(buff, tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Report.Write() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(buff.String(), want) {
+					t.Errorf("Report.Write() = %s, want to contain %s", buff, want)
+				}
+			}
+		})
+	}
+}
+
+func TestReport_WriteJSON(t *testing.T) {
+	buff := bytes.NewBuffer(nil)
+	if err := newTestReport().Write(buff, FormatJSON); err != nil {
+		t.Fatalf("Report.Write() error = %v", err)
+	}
+
+	var got jsonReport
+	if err := json.Unmarshal(buff.Bytes(), &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if got.Commits != 2 || got.Failed != 1 || len(got.Violations) != 2 {
+		t.Fatalf("Report.Write() = %+v", got)
+	}
+	if v := got.Violations[0]; v.Commit != "fedcba9876543210" || v.Field != "Type" || v.Author != "John" {
+		t.Fatalf("Report.Write() violation = %+v", v)
+	}
+}
@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// CommitOptions are options of commit created from staged changes.
+type CommitOptions struct {
+	// Amend replaces HEAD commit keeping its author, staged changes are
+	// optional.
+	Amend bool
+	// Signoff adds Signed-off-by trailer with the committer identity.
+	Signoff bool
+}
+
+// Commit creates commit of staged changes with the message cleaned up like
+// "git commit" does. Author and committer are read from GIT_AUTHOR_* and
+// GIT_COMMITTER_* environment variables or git config. [ErrNothingStaged] is
+// returned when there are no staged changes, unless amending, and
+// [ErrAmendMerge] when amending merge commit.
+func (r *Repository) Commit(message string, opts CommitOptions) (Commit, error) {
+	if !opts.Amend {
+		staged, err := r.Staged()
+		if err != nil {
+			return Commit{}, err
+		}
+		if !staged {
+			return Commit{}, ErrNothingStaged
+		}
+	}
+
+	author, err := r.identity(roleAuthor)
+	if err != nil {
+		return Commit{}, err
+	}
+	committer, err := r.identity(roleCommitter)
+	if err != nil {
+		return Commit{}, err
+	}
+	if opts.Amend {
+		head, err := r.headCommit()
+		if err != nil {
+			return Commit{}, err
+		}
+		// go-git keeps only the first parent of amended commit.
+		if head.NumParents() > 1 {
+			return Commit{}, ErrAmendMerge
+		}
+		author = head.Author
+	}
+
+	message = CleanMessage(message, r.CommentChar())
+	if opts.Signoff {
+		message = addTrailer(message, fmt.Sprintf("Signed-off-by: %s <%s>", committer.Name, committer.Email))
+	}
+
+	wt, err := r.git.Worktree()
+	if err != nil {
+		return Commit{}, fmt.Errorf("worktree: %w", err)
+	}
+	hash, err := wt.Commit(message+"\n", &git.CommitOptions{
+		Author:    &author,
+		Committer: &committer,
+		Amend:     opts.Amend,
+		// Amending may only reword the message, staged changes were checked
+		// otherwise.
+		AllowEmptyCommits: true,
+	})
+	if err != nil {
+		return Commit{}, fmt.Errorf("git commit: %w", err)
+	}
+	c, err := r.git.CommitObject(hash)
+	if err != nil {
+		return Commit{}, fmt.Errorf("read commit: %w", err)
+	}
+	return newCommit(c), nil
+}
+
+// Staged returns true if index has changes to be committed.
+func (r *Repository) Staged() (bool, error) {
+	wt, err := r.git.Worktree()
+	if err != nil {
+		return false, fmt.Errorf("worktree: %w", err)
+	}
+	status, err := wt.Status()
+	if err != nil {
+		return false, fmt.Errorf("git status: %w", err)
+	}
+	for _, s := range status {
+		if s.Staging != git.Unmodified && s.Staging != git.Untracked {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// identity returns signature of commit author or committer. Environment
+// variables take precedence over author and committer sections of git config,
+// which take precedence over its user section.
+func (r *Repository) identity(role string) (object.Signature, error) {
+	cfg, err := r.git.ConfigScoped(config.GlobalScope)
+	if err != nil {
+		return object.Signature{}, fmt.Errorf("read git config: %w", err)
+	}
+
+	name, email := cfg.User.Name, cfg.User.Email
+	section := cfg.Author
+	if role == roleCommitter {
+		section = cfg.Committer
+	}
+	name, email = cmp.Or(section.Name, name), cmp.Or(section.Email, email)
+
+	env := "GIT_" + strings.ToUpper(role)
+	name = cmp.Or(os.Getenv(env+"_NAME"), name)
+	email = cmp.Or(os.Getenv(env+"_EMAIL"), email)
+	if name == "" || email == "" {
+		return object.Signature{}, fmt.Errorf(
+			"%w: %s, set user.name and user.email in git config", ErrIdentityUnknown, role,
+		)
+	}
+	return object.Signature{Name: name, Email: email, When: time.Now()}, nil
+}
+
+const (
+	roleAuthor    = "author"
+	roleCommitter = "committer"
+)
+
+// addTrailer appends trailer line to the trailers paragraph ending the
+// message, or to the message as a new paragraph. Message already containing
+// the trailer is returned unchanged.
+func addTrailer(message, trailer string) string {
+	lines := strings.Split(message, "\n")
+	if slices.Contains(lines, trailer) {
+		return message
+	}
+
+	// Subject is never a trailer, even if it looks like one.
+	last := -1
+	for i, line := range lines {
+		if line == "" {
+			last = i
+		}
+	}
+	if last < 0 {
+		return message + "\n\n" + trailer
+	}
+	for _, line := range lines[last+1:] {
+		if !trailerRgx.MatchString(line) {
+			return message + "\n\n" + trailer
+		}
+	}
+	return message + "\n" + trailer
+}
+
+var trailerRgx = regexp.MustCompile(`^[A-Za-z0-9-]+: \S`)
+
+var (
+	// ErrNothingStaged indicates commit without staged changes.
+	ErrNothingStaged = errors.New("nothing staged to commit, use git add")
+	// ErrAmendMerge indicates amending commit with more than one parent,
+	// which would drop its other parents.
+	ErrAmendMerge = errors.New("can't amend merge commit, use git commit --amend")
+	// ErrIdentityUnknown indicates missing name or email of commit author or
+	// committer.
+	ErrIdentityUnknown = errors.New("identity unknown")
+)
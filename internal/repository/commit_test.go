@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+func TestRepository_Commit(t *testing.T) {
+	tests := []struct {
+		name        string
+		stage       bool
+		merge       bool
+		opts        CommitOptions
+		message     string
+		wantMessage string
+		wantAuthor  string
+		wantCommits int
+		wantErr     error
+	}{
+		{
+			name:        "staged changes",
+			stage:       true,
+			message:     "feat(api): add endpoint\n\n# comment\n",
+			wantMessage: "feat(api): add endpoint",
+			wantAuthor:  "Jane Doe",
+			wantCommits: 2,
+		},
+		{
+			name:    "nothing staged",
+			message: "feat(api): add endpoint",
+			wantErr: ErrNothingStaged,
+		},
+		{
+			name:        "signoff",
+			stage:       true,
+			opts:        CommitOptions{Signoff: true},
+			message:     "fix(db): close connection\n\nCloses #12",
+			wantMessage: "fix(db): close connection\n\nCloses #12\n\nSigned-off-by: John Roe <john@example.com>",
+			wantAuthor:  "Jane Doe",
+			wantCommits: 2,
+		},
+		{
+			name:        "amend keeps author",
+			opts:        CommitOptions{Amend: true},
+			message:     "chore: initial commit",
+			wantMessage: "chore: initial commit",
+			wantAuthor:  "Initial Author",
+			wantCommits: 1,
+		},
+		{
+			name:    "amend merge commit",
+			merge:   true,
+			opts:    CommitOptions{Amend: true},
+			message: "Merge branch 'topic'",
+			wantErr: ErrAmendMerge,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := newTestRepository(t)
+			if tt.merge {
+				commitMerge(t, root)
+			}
+			if tt.stage {
+				stage(t, root, "main.go")
+			}
+			repo, err := Open(root)
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+
+			got, err := repo.Commit(tt.message, tt.opts)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Commit() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Commit() error = %v", err)
+			}
+			if got.Message != tt.wantMessage {
+				t.Errorf("Commit() message = %q, want %q", got.Message, tt.wantMessage)
+			}
+			if got.Author != tt.wantAuthor {
+				t.Errorf("Commit() author = %q, want %q", got.Author, tt.wantAuthor)
+			}
+
+			log, err := repo.Log(0, nil)
+			if err != nil {
+				t.Fatalf("Log() error = %v", err)
+			}
+			if log[0].Hash != got.Hash {
+				t.Errorf("HEAD = %s, want %s", log[0].Hash, got.Hash)
+			}
+			if len(log) != tt.wantCommits {
+				t.Errorf("len(Log()) = %d, want %d", len(log), tt.wantCommits)
+			}
+		})
+	}
+}
+
+func TestAddTrailer(t *testing.T) {
+	const trailer = "Signed-off-by: Jane Doe <jane@example.com>"
+	tests := []struct {
+		name    string
+		message string
+		want    string
+	}{
+		{
+			name:    "subject only",
+			message: "fix: handle EOF",
+			want:    "fix: handle EOF\n\n" + trailer,
+		},
+		{
+			name:    "subject looking like trailer",
+			message: "Fix: handle EOF",
+			want:    "Fix: handle EOF\n\n" + trailer,
+		},
+		{
+			name:    "body",
+			message: "fix: handle EOF\n\nreader returned EOF",
+			want:    "fix: handle EOF\n\nreader returned EOF\n\n" + trailer,
+		},
+		{
+			name:    "existing trailers",
+			message: "fix: handle EOF\n\nreader returned EOF\n\nRefs: #12\nReviewed-by: John Roe <john@example.com>",
+			want:    "fix: handle EOF\n\nreader returned EOF\n\nRefs: #12\nReviewed-by: John Roe <john@example.com>\n" + trailer,
+		},
+		{
+			name:    "already signed off",
+			message: "fix: handle EOF\n\n" + trailer,
+			want:    "fix: handle EOF\n\n" + trailer,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := addTrailer(tt.message, trailer); got != tt.want {
+				t.Fatalf("addTrailer() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// newTestRepository returns root of new repository with initial commit and
+// identity of the committer set in environment.
+func newTestRepository(t *testing.T) string {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("GIT_AUTHOR_NAME", "Jane Doe")
+	t.Setenv("GIT_AUTHOR_EMAIL", "jane@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "John Roe")
+	t.Setenv("GIT_COMMITTER_EMAIL", "john@example.com")
+
+	root := t.TempDir()
+	repo, err := git.PlainInit(root, false)
+	if err != nil {
+		t.Fatalf("init repository: %v", err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("worktree: %v", err)
+	}
+	_, err = wt.Commit("chore: init", &git.CommitOptions{
+		AllowEmptyCommits: true,
+		Author:            &object.Signature{Name: "Initial Author", Email: "initial@example.com"},
+	})
+	if err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	return root
+}
+
+// commitMerge commits merge of HEAD and a new commit on top of its parent.
+func commitMerge(t *testing.T, root string) {
+	t.Helper()
+	repo, err := git.PlainOpen(root)
+	if err != nil {
+		t.Fatalf("open repository: %v", err)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatalf("head: %v", err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("worktree: %v", err)
+	}
+	sig := &object.Signature{Name: "Jane Doe", Email: "jane@example.com"}
+	topic, err := wt.Commit("feat: topic", &git.CommitOptions{
+		AllowEmptyCommits: true,
+		Author:            sig,
+		Parents:           []plumbing.Hash{head.Hash()},
+	})
+	if err != nil {
+		t.Fatalf("commit topic: %v", err)
+	}
+	_, err = wt.Commit("Merge branch 'topic'", &git.CommitOptions{
+		AllowEmptyCommits: true,
+		Author:            sig,
+		Parents:           []plumbing.Hash{head.Hash(), topic},
+	})
+	if err != nil {
+		t.Fatalf("commit merge: %v", err)
+	}
+}
+
+// stage writes the file in repository root and adds it to the index.
+func stage(t *testing.T, root, name string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(root, name), []byte("package main\n"), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	repo, err := git.PlainOpen(root)
+	if err != nil {
+		t.Fatalf("open repository: %v", err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("worktree: %v", err)
+	}
+	if _, err := wt.Add(name); err != nil {
+		t.Fatalf("add %s: %v", name, err)
+	}
+}
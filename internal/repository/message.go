@@ -1,96 +1,503 @@
 package repository
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
-)
-
-var (
-	templateParamsRgx    = regexp.MustCompile(`{{\.\w+}}`)
-	templateParamNameRgx = regexp.MustCompile(`\w+`)
+	"text/template/parse"
 )
 
 // Message is a key-value store of commit message parameters, where key is the
 // name of provided in template field and value - matching pattern text.
 type Message map[string]string
 
+// Reserved message fields filled with commit metadata instead of values
+// parsed from the template.
+const (
+	// FieldHash is the commit hash.
+	FieldHash = "Hash"
+	// FieldTemplate is the name of matched template.
+	FieldTemplate = "Template"
+)
+
 // ParseMessage returns message with parsed from template and commit message
 // parameters.
 func ParseMessage(template, message string, required ...string) (Message, error) {
-	tmplParams := map[string]string{}
-	bParams := templateParamsRgx.FindAllStringIndex(template, -1)
-	cursor := 0
+	p, err := NewParser(template)
+	if err != nil {
+		return nil, err
+	}
+	return p.Parse(message, required...)
+}
 
-	for i, bp := range bParams {
-		if len(message[cursor:]) == 0 {
-			break
+// CleanMessage returns message from commit message file the way git stores it
+// with default cleanup mode: without comment lines, everything below scissors
+// line, trailing whitespace and consecutive blank lines.
+func CleanMessage(text, commentChar string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	var b strings.Builder
+	blank := false
+	for _, line := range lines {
+		if commentChar != "" && strings.HasPrefix(line, commentChar) {
+			if strings.HasPrefix(line, commentChar+scissorsLine) {
+				break
+			}
+			continue
 		}
 
-		ph := placeholder{
-			beginIdx: bp[0],
-			endIdx:   bp[1],
+		line = strings.TrimRight(line, whitespace)
+		if line == "" {
+			blank = b.Len() != 0
+			continue
 		}
-		paramName := templateParamNameRgx.FindString(
-			template[ph.beginIdx:ph.endIdx],
-		)
+		if blank {
+			b.WriteString("\n")
+			blank = false
+		}
+		if b.Len() != 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(line)
+	}
+	return b.String()
+}
 
-		ph.sepBeginIdx = ph.endIdx
-		if next, ok := next(bParams, i); ok {
-			ph.sepEndIdx = next[0]
-		} else {
-			ph.sepEndIdx = len(template)
+// scissorsLine marks the end of commit message in verbose commit message
+// file, everything below is the diff.
+const scissorsLine = " ------------------------ >8 ------------------------"
+
+// Parser reads commit message parameters back from messages rendered with
+// the template.
+//
+// Template is expanded into variants - one for each combination of taken
+// "if", "with" and "range" branches. Message is matched against every
+// variant and the most specific one, matching the most of template's literal
+// text, wins. Git strips trailing whitespace and squashes blank lines, so
+// line breaks are matched loosely and trailing fields are optional.
+type Parser struct {
+	template string
+	variants []*variant
+	fields   []string
+}
+
+// NewParser returns parser of messages generated with given template.
+func NewParser(template string) (*Parser, error) {
+	// Functions are irrelevant for the message structure, so they are not
+	// checked, letting templates use any of the functions available while
+	// rendering.
+	tree := parse.New("message")
+	tree.Mode = parse.SkipFuncCheck
+	if _, err := tree.Parse(template, "", "", map[string]*parse.Tree{}); err != nil {
+		return nil, fmt.Errorf("parse template: %w", err)
+	}
+
+	p := &Parser{template: template}
+
+	seqs, err := expandList(tree.Root, scope{capture: true})
+	if err != nil {
+		return nil, err
+	}
+
+	known := map[string]bool{}
+	for _, seq := range seqs {
+		v, err := newVariant(seq)
+		if err != nil {
+			return nil, err
 		}
+		p.variants = append(p.variants, v)
 
-		phraseSep := template[ph.sepBeginIdx:ph.sepEndIdx]
-		if len(phraseSep) == 0 {
-			tmplParams[paramName] = message[cursor:]
-			break
+		for _, field := range v.groups {
+			if !known[field] {
+				known[field] = true
+				p.fields = append(p.fields, field)
+			}
 		}
+	}
 
-		phraseEndIdx := strings.Index(message[cursor:], phraseSep)
-		if phraseEndIdx < 0 {
-			tmplParams[paramName] = message[cursor:]
-			break
+	return p, nil
+}
+
+// Fields returns names of template fields that parser reads from message.
+func (p *Parser) Fields() []string {
+	return p.fields
+}
+
+// Match returns message parameters of the best matching template variant.
+// False is returned when message doesn't match the template at all.
+func (p *Parser) Match(message string) (Message, bool) {
+	message = strings.TrimSpace(message)
+
+	var (
+		best     Message
+		bestVar  *variant
+		matching bool
+	)
+	for _, v := range p.variants {
+		msg, ok := v.match(message)
+		if !ok {
+			continue
 		}
+		if !matching || v.isMoreSpecific(bestVar) {
+			best, bestVar, matching = msg, v, true
+		}
+	}
+	return best, matching
+}
 
-		phrase := message[cursor : cursor+phraseEndIdx]
-		tmplParams[paramName] = phrase
-		cursor += len(phrase) + len(phraseSep)
+// Parse returns message parameters and fails when any of required parameters
+// is missing.
+func (p *Parser) Parse(message string, required ...string) (Message, error) {
+	msg, ok := p.Match(message)
+	if !ok {
+		msg = Message{}
 	}
 
 	for _, r := range required {
-		if tmplParams[r] == "" {
-			return nil, fmt.Errorf(
-				missingRequiredErrMsg,
-				template,
-				strings.Join(required, ","),
-				r,
-			)
+		if msg[r] == "" {
+			return nil, &MissingFieldError{
+				Template: p.template,
+				Required: required,
+				Field:    r,
+			}
 		}
 	}
 
-	return tmplParams, nil
+	return msg, nil
 }
 
-type placeholder struct {
-	beginIdx    int
-	endIdx      int
-	sepBeginIdx int
-	sepEndIdx   int
+// MissingFieldError indicates that required message parameter is missing.
+type MissingFieldError struct {
+	Template string
+	Required []string
+	Field    string
 }
 
-func isIndexLast[T any](slice []T, idx int) bool {
-	return idx == len(slice)-1
+func (e *MissingFieldError) Error() string {
+	return fmt.Sprintf(
+		missingRequiredErrMsg,
+		e.Template,
+		strings.Join(e.Required, ","),
+		e.Field,
+	)
 }
 
-func next[T any](slice []T, idx int) (ret T, ok bool) {
-	if isIndexLast(slice, idx) {
-		return
+// segment is a part of rendered template - literal text, captured field
+// value or any other text generated by template action.
+type segment struct {
+	text     string
+	field    string
+	wildcard bool
+}
+
+func (s segment) isLiteral() bool {
+	return s.field == "" && !s.wildcard
+}
+
+// sequence is a flat list of segments of one template variant with fields
+// that are non-empty when conditional branches are taken.
+type sequence struct {
+	segments []segment
+	nonEmpty []string
+}
+
+// scope of template execution where dot is the name of field referenced by
+// "{{.}}" and capture disables capturing fields inside "range".
+type scope struct {
+	dot     string
+	capture bool
+}
+
+func expandList(list *parse.ListNode, sc scope) ([]sequence, error) {
+	seqs := []sequence{{}}
+	if list == nil {
+		return seqs, nil
+	}
+	for _, node := range list.Nodes {
+		alts, err := expandNode(node, sc)
+		if err != nil {
+			return nil, err
+		}
+		seqs, err = combine(seqs, alts)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return seqs, nil
+}
+
+func expandNode(node parse.Node, sc scope) ([]sequence, error) {
+	switch n := node.(type) {
+	case *parse.TextNode:
+		return []sequence{{segments: []segment{{text: string(n.Text)}}}}, nil
+	case *parse.ActionNode:
+		if len(n.Pipe.Decl) != 0 {
+			// Variable declaration doesn't render anything.
+			return []sequence{{}}, nil
+		}
+		if field := pipeField(n.Pipe, sc); field != "" && sc.capture {
+			return []sequence{{segments: []segment{{field: field}}}}, nil
+		}
+		return []sequence{{segments: []segment{{wildcard: true}}}}, nil
+	case *parse.IfNode:
+		return expandBranch(&n.BranchNode, sc, conditionFields(n.Pipe, sc))
+	case *parse.WithNode:
+		inner := sc
+		inner.dot = pipeField(n.Pipe, sc)
+		return expandBranchScope(&n.BranchNode, sc, inner, conditionFields(n.Pipe, sc))
+	case *parse.RangeNode:
+		inner := sc
+		inner.dot, inner.capture = "", false
+		return expandBranchScope(&n.BranchNode, sc, inner, nil)
+	case *parse.TemplateNode:
+		return []sequence{{segments: []segment{{wildcard: true}}}}, nil
+	default:
+		// Comments, break and continue don't render anything.
+		return []sequence{{}}, nil
 	}
-	return slice[idx+1], true
 }
 
+func expandBranch(n *parse.BranchNode, sc scope, cond []string) ([]sequence, error) {
+	return expandBranchScope(n, sc, sc, cond)
+}
+
+// expandBranchScope returns variants of taken branch, followed by variants
+// of else branch.
+func expandBranchScope(n *parse.BranchNode, sc, inner scope, cond []string) ([]sequence, error) {
+	taken, err := expandList(n.List, inner)
+	if err != nil {
+		return nil, err
+	}
+	for i := range taken {
+		taken[i].nonEmpty = append(taken[i].nonEmpty, cond...)
+	}
+
+	otherwise, err := expandList(n.ElseList, sc)
+	if err != nil {
+		return nil, err
+	}
+	return append(taken, otherwise...), nil
+}
+
+// combine returns every variant of prefix followed by every variant of
+// suffix.
+func combine(prefixes, suffixes []sequence) ([]sequence, error) {
+	if len(prefixes)*len(suffixes) > maxTemplateVariants {
+		return nil, ErrTemplateTooComplex
+	}
+	result := make([]sequence, 0, len(prefixes)*len(suffixes))
+	for _, p := range prefixes {
+		for _, s := range suffixes {
+			result = append(result, sequence{
+				segments: concat(p.segments, s.segments),
+				nonEmpty: concat(p.nonEmpty, s.nonEmpty),
+			})
+		}
+	}
+	return result, nil
+}
+
+func concat[T any](a, b []T) []T {
+	result := make([]T, 0, len(a)+len(b))
+	return append(append(result, a...), b...)
+}
+
+// pipeField returns name of the field when pipeline is a plain field
+// reference, e.g. "{{.Scope}}" or "{{.}}" inside "with".
+func pipeField(pipe *parse.PipeNode, sc scope) string {
+	if pipe == nil || len(pipe.Cmds) != 1 || len(pipe.Cmds[0].Args) != 1 {
+		return ""
+	}
+	switch arg := pipe.Cmds[0].Args[0].(type) {
+	case *parse.FieldNode:
+		if len(arg.Ident) == 1 {
+			return arg.Ident[0]
+		}
+	case *parse.DotNode:
+		return sc.dot
+	case *parse.PipeNode:
+		return pipeField(arg, sc)
+	}
+	return ""
+}
+
+// conditionFields returns fields that have to be non-empty for condition to
+// be true, e.g. "{{if .Task}}" or "{{if (ne .Task "")}}".
+func conditionFields(pipe *parse.PipeNode, sc scope) []string {
+	if field := pipeField(pipe, sc); field != "" {
+		return []string{field}
+	}
+	if pipe == nil || len(pipe.Cmds) != 1 {
+		return nil
+	}
+	args := pipe.Cmds[0].Args
+	if len(args) == 1 {
+		if inner, ok := args[0].(*parse.PipeNode); ok {
+			return conditionFields(inner, sc)
+		}
+		return nil
+	}
+	if len(args) != 3 || args[0].String() != "ne" {
+		return nil
+	}
+	for i, arg := range args[1:] {
+		other := args[2-i]
+		str, ok := other.(*parse.StringNode)
+		if !ok || str.Text != "" {
+			continue
+		}
+		if field, ok := arg.(*parse.FieldNode); ok && len(field.Ident) == 1 {
+			return []string{field.Ident[0]}
+		}
+	}
+	return nil
+}
+
+// variant is a compiled template variant.
+type variant struct {
+	rgx      *regexp.Regexp
+	groups   []string
+	nonEmpty []string
+	// literal and text are the numbers of non-whitespace and all literal
+	// characters, used to pick the most specific matching variant.
+	literal int
+	text    int
+}
+
+func newVariant(seq sequence) (*variant, error) {
+	segments := trimSegments(mergeLiterals(seq.segments))
+
+	// Everything after the last non-whitespace literal might be dropped
+	// from the message when trailing fields are empty.
+	optionalFrom := 0
+	for i, s := range segments {
+		if s.isLiteral() && strings.TrimSpace(s.text) != "" {
+			optionalFrom = i + 1
+		}
+	}
+
+	v := &variant{nonEmpty: seq.nonEmpty}
+
+	var b strings.Builder
+	b.WriteString(`(?s)^`)
+	optionalGroups := 0
+	for i, s := range segments {
+		if i >= optionalFrom && (i == optionalFrom || !segments[i-1].isLiteral()) {
+			b.WriteString(`(?:`)
+			optionalGroups++
+		}
+		switch {
+		case s.field != "":
+			b.WriteString(`(.*?)`)
+			v.groups = append(v.groups, s.field)
+		case s.wildcard:
+			b.WriteString(`.*?`)
+		default:
+			b.WriteString(literalPattern(s.text))
+			v.literal += len(strings.Join(strings.Fields(s.text), ""))
+			v.text += len(s.text)
+		}
+	}
+	b.WriteString(strings.Repeat(`)?`, optionalGroups))
+	b.WriteString(`$`)
+
+	rgx, err := regexp.Compile(b.String())
+	if err != nil {
+		return nil, fmt.Errorf("compile template variant: %w", err)
+	}
+	v.rgx = rgx
+
+	return v, nil
+}
+
+func (v *variant) match(message string) (Message, bool) {
+	idx := v.rgx.FindStringSubmatchIndex(message)
+	if idx == nil {
+		return nil, false
+	}
+
+	msg := Message{}
+	for i, field := range v.groups {
+		begin, end := idx[2*(i+1)], idx[2*(i+1)+1]
+		if begin < 0 {
+			continue
+		}
+		value := strings.TrimSpace(message[begin:end])
+		if prev, found := msg[field]; found && prev != value {
+			// Field repeated in template must have the same value.
+			return nil, false
+		}
+		msg[field] = value
+	}
+	for _, field := range v.nonEmpty {
+		if _, captured := msg[field]; captured && msg[field] == "" {
+			return nil, false
+		}
+	}
+	return msg, true
+}
+
+func (v *variant) isMoreSpecific(other *variant) bool {
+	if v.literal != other.literal {
+		return v.literal > other.literal
+	}
+	return v.text > other.text
+}
+
+func mergeLiterals(segments []segment) []segment {
+	result := make([]segment, 0, len(segments))
+	for _, s := range segments {
+		if n := len(result); n != 0 && s.isLiteral() && result[n-1].isLiteral() {
+			result[n-1].text += s.text
+			continue
+		}
+		result = append(result, s)
+	}
+	return result
+}
+
+// trimSegments removes leading and trailing whitespace of the rendered
+// template, as git does with commit messages.
+func trimSegments(segments []segment) []segment {
+	for len(segments) != 0 && segments[0].isLiteral() {
+		segments[0].text = strings.TrimLeft(segments[0].text, whitespace)
+		if segments[0].text != "" {
+			break
+		}
+		segments = segments[1:]
+	}
+	for n := len(segments); n != 0 && segments[n-1].isLiteral(); n = len(segments) {
+		segments[n-1].text = strings.TrimRight(segments[n-1].text, whitespace)
+		if segments[n-1].text != "" {
+			break
+		}
+		segments = segments[:n-1]
+	}
+	return segments
+}
+
+// literalPattern returns pattern of template text, where every line break
+// matches one or more line breaks preceded by optional trailing spaces.
+func literalPattern(text string) string {
+	parts := lineBreaksRgx.Split(text, -1)
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	return strings.Join(parts, lineBreaksRgx.String())
+}
+
+var lineBreaksRgx = regexp.MustCompile(`(?:[ \t]*\n)+`)
+
+const (
+	whitespace          = " \t\n\r"
+	maxTemplateVariants = 256
+)
+
+// ErrTemplateTooComplex indicates that template has too many conditional
+// branches to be parsed.
+var ErrTemplateTooComplex = errors.New("template too complex")
+
 const missingRequiredErrMsg = `
 missing required message parameter:
 -----------------------------------
@@ -5,6 +5,15 @@ import (
 	"testing"
 )
 
+const conditionalTemplate = `{{.Type}}({{.Scope}}): {{.Message}}
+{{if (ne .Description "") }}
+{{.Description}}
+{{end}}
+{{- if (ne .Task "") }}
+Closes #{{.Task}}
+{{- end}}
+`
+
 func TestParseMessage(t *testing.T) {
 	type args struct {
 		template string
@@ -58,6 +67,96 @@ description`,
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "parses conditional description and task",
+			args: args{
+				template: conditionalTemplate,
+				message:  "feat(api): add endpoint\n\nfirst line\nsecond line\n\nCloses #TASK-1",
+				required: []string{"Type", "Scope", "Message"},
+			},
+			want: Message{
+				"Type":        "feat",
+				"Scope":       "api",
+				"Message":     "add endpoint",
+				"Description": "first line\nsecond line",
+				"Task":        "TASK-1",
+			},
+		},
+		{
+			name: "parses conditional task without description",
+			args: args{
+				template: conditionalTemplate,
+				message:  "fix(db): close connection\n\nCloses #12",
+				required: []string{"Type", "Scope", "Message"},
+			},
+			want: Message{
+				"Type":    "fix",
+				"Scope":   "db",
+				"Message": "close connection",
+				"Task":    "12",
+			},
+		},
+		{
+			name: "parses conditional description without task",
+			args: args{
+				template: conditionalTemplate,
+				message:  "fix(db): close connection\n\nleaked on error\n",
+				required: []string{"Type", "Scope", "Message"},
+			},
+			want: Message{
+				"Type":        "fix",
+				"Scope":       "db",
+				"Message":     "close connection",
+				"Description": "leaked on error",
+			},
+		},
+		{
+			name: "parses subject only with conditional blocks",
+			args: args{
+				template: conditionalTemplate,
+				message:  "docs(readme): usage",
+				required: []string{"Type", "Scope", "Message"},
+			},
+			want: Message{
+				"Type":    "docs",
+				"Scope":   "readme",
+				"Message": "usage",
+			},
+		},
+		{
+			name: "parses else branch",
+			args: args{
+				template: `{{.Type}}{{if .Scope}}({{.Scope}}){{else}} [global]{{end}}: {{.Message}}`,
+				message:  "ci [global]: cache modules",
+				required: []string{"Type", "Message"},
+			},
+			want: Message{
+				"Type":    "ci",
+				"Message": "cache modules",
+			},
+		},
+		{
+			name: "parses with block dot",
+			args: args{
+				template: `{{.Type}}{{with .Scope}}({{.}}){{end}}: {{.Message}}`,
+				message:  "ci(lint): cache modules",
+				required: []string{"Type", "Scope", "Message"},
+			},
+			want: Message{
+				"Type":    "ci",
+				"Scope":   "lint",
+				"Message": "cache modules",
+			},
+		},
+		{
+			name: "fails on invalid template",
+			args: args{
+				template: `{{.Type}: {{.Message}}`,
+				message:  "ci: cache modules",
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name: "parses on missing template match",
 			args: args{
@@ -82,3 +181,40 @@ description`,
 		})
 	}
 }
+
+func TestCleanMessage(t *testing.T) {
+	tests := []struct {
+		name        string
+		text        string
+		commentChar string
+		want        string
+	}{
+		{
+			name:        "strips comments and blank lines",
+			text:        "\nfeat(api): add endpoint  \n\n\n\ndescription\n# Please enter the commit message\n#\n\n",
+			commentChar: "#",
+			want:        "feat(api): add endpoint\n\ndescription",
+		},
+		{
+			name:        "keeps hash lines with custom comment char",
+			text:        "fix(db): close connection\n\nCloses #12\n; comment\n",
+			commentChar: ";",
+			want:        "fix(db): close connection\n\nCloses #12",
+		},
+		{
+			name: "cuts at scissors line",
+			text: "docs: usage\n" +
+				"# ------------------------ >8 ------------------------\n" +
+				"diff --git a/README.md b/README.md\n",
+			commentChar: "#",
+			want:        "docs: usage",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CleanMessage(tt.text, tt.commentChar); got != tt.want {
+				t.Fatalf("CleanMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// Range returns commits of revision range, newest first. "A..B" range has
+// commits reachable from B, but not from A. Single revision has commits not
+// reachable from remote-tracking branches and tags, e.g. new branch pushed
+// for the first time. Unknown A, e.g. force-pushed remote commit not fetched,
+// is handled like single revision.
+func (r *Repository) Range(rng string) ([]Commit, error) {
+	from, to, isRange := strings.Cut(rng, "..")
+	if !isRange {
+		to, from = from, ""
+	}
+
+	head, err := r.git.ResolveRevision(plumbing.Revision(to))
+	if err != nil {
+		return nil, fmt.Errorf("resolve %s: %w", to, err)
+	}
+
+	var exclude []plumbing.Hash
+	if from != "" {
+		if hash, err := r.git.ResolveRevision(plumbing.Revision(from)); err == nil {
+			exclude = append(exclude, *hash)
+		}
+	}
+	if len(exclude) == 0 {
+		exclude, err = r.publishedHashes()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	excluded, err := r.ancestors(exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []*object.Commit
+	seen := map[plumbing.Hash]bool{}
+	stack := []plumbing.Hash{*head}
+	for len(stack) > 0 {
+		hash := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if seen[hash] || excluded[hash] {
+			continue
+		}
+		seen[hash] = true
+
+		c, err := r.git.CommitObject(hash)
+		if err != nil {
+			return nil, fmt.Errorf("read commit %s: %w", hash, err)
+		}
+		commits = append(commits, c)
+		stack = append(stack, c.ParentHashes...)
+	}
+
+	sort.SliceStable(commits, func(i, j int) bool {
+		return commits[i].Committer.When.After(commits[j].Committer.When)
+	})
+	result := make([]Commit, 0, len(commits))
+	for _, c := range commits {
+		result = append(result, newCommit(c))
+	}
+	return result, nil
+}
+
+// publishedHashes returns commits of remote-tracking branches and tags.
+func (r *Repository) publishedHashes() ([]plumbing.Hash, error) {
+	refs, err := r.git.References()
+	if err != nil {
+		return nil, fmt.Errorf("git references: %w", err)
+	}
+	defer refs.Close()
+
+	var hashes []plumbing.Hash
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() != plumbing.HashReference {
+			return nil
+		}
+		if !ref.Name().IsRemote() && !ref.Name().IsTag() {
+			return nil
+		}
+		if c, err := r.taggedCommit(ref.Hash()); err == nil {
+			hashes = append(hashes, c.Hash)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("for each reference: %w", err)
+	}
+	return hashes, nil
+}
+
+// ancestors returns set of the commits and all their ancestors.
+func (r *Repository) ancestors(hashes []plumbing.Hash) (map[plumbing.Hash]bool, error) {
+	set := map[plumbing.Hash]bool{}
+	for _, hash := range hashes {
+		if set[hash] {
+			continue
+		}
+		c, err := r.git.CommitObject(hash)
+		if err != nil {
+			return nil, fmt.Errorf("read commit %s: %w", hash, err)
+		}
+		// Commits already in the set are not walked again.
+		err = object.NewCommitPreorderIter(c, set, nil).ForEach(func(c *object.Commit) error {
+			set[c.Hash] = true
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("walk ancestors of %s: %w", hash, err)
+		}
+	}
+	return set, nil
+}
@@ -4,11 +4,15 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/filesystem"
 
 	"github.com/kam9lo/gover/internal/version"
 )
@@ -17,18 +21,30 @@ import (
 // with git.
 type Repository struct {
 	git *git.Repository
+	// tagPrefix limits version tags to ones with the prefix, if not empty.
+	tagPrefix string
 }
 
-// Open returns git repository if exists, otherwise fails with an
-// error.
+// Open returns git repository containing given path if exists, otherwise
+// fails with an error.
 func Open(path string) (*Repository, error) {
-	repo, err := git.PlainOpen(path)
+	repo, err := git.PlainOpenWithOptions(path, &git.PlainOpenOptions{
+		DetectDotGit: true,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("open repository: %w", err)
 	}
 	return &Repository{git: repo}, nil
 }
 
+// SetTagPrefix limits tags considered by [Repository.LatestTag] and
+// [Repository.FeatureCommits] to versions with given prefix, e.g. "v" for
+// repositories tagging also other components, like "tools-1.0.0". All tags
+// are considered when prefix is empty.
+func (r *Repository) SetTagPrefix(prefix string) {
+	r.tagPrefix = prefix
+}
+
 // LatestTag returns latest known in repository tag.
 func (r *Repository) LatestTag() (string, error) {
 	tags, err := r.latestTags()
@@ -81,19 +97,230 @@ func (r *Repository) CreateTag(name string) error {
 	return err
 }
 
+// Commit is a git commit with metadata relevant for commit message
+// processing.
+type Commit struct {
+	Hash    string
+	Author  string
+	Email   string
+	Message string
+	// Merge is true for commits with more than one parent.
+	Merge bool
+}
+
+// String implements [fmt.Stringer] and returns commit message.
+func (c Commit) String() string {
+	return c.Message
+}
+
+// Subject returns first line of commit message.
+func (c Commit) Subject() string {
+	subject, _, _ := strings.Cut(c.Message, "\n")
+	return subject
+}
+
 // FeatureCommits returns commits since last known tag.
-func (r *Repository) FeatureCommits() ([]string, error) {
+func (r *Repository) FeatureCommits() ([]Commit, error) {
 	commits, err := r.featureCommits()
 	if err != nil {
 		return nil, err
 	}
 
-	messages := make([]string, 0, len(commits))
+	result := make([]Commit, 0, len(commits))
 	for _, c := range commits {
-		messages = append(messages, strings.Trim(c.Message, "\n"))
+		result = append(result, newCommit(c))
 	}
 
-	return messages, nil
+	return result, nil
+}
+
+func newCommit(c *object.Commit) Commit {
+	return Commit{
+		Hash:    c.Hash.String(),
+		Author:  c.Author.Name,
+		Email:   c.Author.Email,
+		Message: strings.Trim(c.Message, "\n"),
+		Merge:   c.NumParents() > 1,
+	}
+}
+
+// Signature returns commit author in "Name <email>" format.
+func (c Commit) Signature() string {
+	return fmt.Sprintf("%s <%s>", c.Author, c.Email)
+}
+
+// Root returns path of repository working tree.
+func (r *Repository) Root() (string, error) {
+	wt, err := r.git.Worktree()
+	if err != nil {
+		return "", fmt.Errorf("worktree: %w", err)
+	}
+	return wt.Filesystem.Root(), nil
+}
+
+// HooksDir returns directory of git hooks, configured with core.hooksPath or
+// "hooks" in git directory. Relative core.hooksPath is resolved from working
+// tree root, where git runs hooks. Shared is true when core.hooksPath is set
+// only in global config, so hooks in the directory run in all repositories.
+func (r *Repository) HooksDir() (dir string, shared bool, err error) {
+	root, err := r.Root()
+	if err != nil {
+		return "", false, err
+	}
+	path, shared, err := r.hooksPath()
+	if err != nil {
+		return "", false, err
+	}
+	if path != "" {
+		if rest, ok := strings.CutPrefix(path, "~/"); ok {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return "", false, fmt.Errorf("resolve core.hooksPath: %w", err)
+			}
+			path = filepath.Join(home, rest)
+		}
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(root, path)
+		}
+		return path, shared, nil
+	}
+
+	dir, err = r.gitDir()
+	if err != nil {
+		return "", false, err
+	}
+	// Linked worktrees share hooks of the main git directory.
+	if common, err := os.ReadFile(filepath.Join(dir, "commondir")); err == nil {
+		path := strings.TrimSpace(string(common))
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		dir = path
+	}
+	return filepath.Join(dir, "hooks"), false, nil
+}
+
+// hooksPath returns core.hooksPath set in repository config, or in global
+// config with shared set. Local config is read separately, as scoped config
+// doesn't merge sections present in both.
+func (r *Repository) hooksPath() (path string, shared bool, err error) {
+	local, err := r.git.Config()
+	if err != nil {
+		return "", false, fmt.Errorf("read git config: %w", err)
+	}
+	if path := local.Raw.Section("core").Option("hooksPath"); path != "" {
+		return path, false, nil
+	}
+	global, err := config.LoadConfig(config.GlobalScope)
+	if err != nil {
+		return "", false, fmt.Errorf("read global git config: %w", err)
+	}
+	path = global.Raw.Section("core").Option("hooksPath")
+	return path, path != "", nil
+}
+
+// Merging returns true if merge is in progress, so the commit being created
+// has more than one parent.
+func (r *Repository) Merging() (bool, error) {
+	dir, err := r.gitDir()
+	if err != nil {
+		return false, err
+	}
+	_, err = os.Stat(filepath.Join(dir, "MERGE_HEAD"))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("merge state: %w", err)
+	}
+	return true, nil
+}
+
+// gitDir returns git directory of the working tree.
+func (r *Repository) gitDir() (string, error) {
+	storage, ok := r.git.Storer.(*filesystem.Storage)
+	if !ok {
+		return "", errors.New("repository without git directory")
+	}
+	return storage.Filesystem().Root(), nil
+}
+
+// Log returns commits reachable from HEAD, newest first. Limit restricts
+// number of returned commits, zero returns all. Commits with excluded hashes
+// are skipped and don't count towards the limit. Repository without commits
+// has empty log.
+func (r *Repository) Log(limit int, exclude map[string]bool) ([]Commit, error) {
+	log, err := r.git.Log(&git.LogOptions{
+		Order: git.LogOrderCommitterTime,
+	})
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("git log: %w", err)
+	}
+	defer log.Close()
+
+	var result []Commit
+	err = log.ForEach(func(c *object.Commit) error {
+		if limit > 0 && len(result) == limit {
+			return io.EOF
+		}
+		if exclude[c.Hash.String()] {
+			return nil
+		}
+		result = append(result, newCommit(c))
+		return nil
+	})
+	if errors.Is(err, io.EOF) {
+		err = nil
+	}
+	return result, err
+}
+
+// Tags returns names of all tags.
+func (r *Repository) Tags() ([]string, error) {
+	tags, err := r.git.Tags()
+	if err != nil {
+		return nil, fmt.Errorf("git tags: %w", err)
+	}
+	defer tags.Close()
+
+	var names []string
+	err = tags.ForEach(func(ref *plumbing.Reference) error {
+		names = append(names, ref.Name().Short())
+		return nil
+	})
+	return names, err
+}
+
+// CommentChar returns character that starts comment lines in commit message
+// file, configured with core.commentChar.
+func (r *Repository) CommentChar() string {
+	cfg, err := r.git.ConfigScoped(config.GlobalScope)
+	if err != nil {
+		return defaultCommentChar
+	}
+	char := cfg.Raw.Section("core").Option("commentChar")
+	if char == "" || char == "auto" {
+		// With "auto" git picks a character unused in the message, which
+		// is "#" unless message lines start with it.
+		return defaultCommentChar
+	}
+	return char
+}
+
+// RemoteURL returns first configured URL of remote with given name.
+func (r *Repository) RemoteURL(name string) (string, error) {
+	remote, err := r.git.Remote(name)
+	if err != nil {
+		return "", fmt.Errorf("remote %s: %w", name, err)
+	}
+	urls := remote.Config().URLs
+	if len(urls) == 0 {
+		return "", fmt.Errorf("remote %s has no urls", name)
+	}
+	return urls[0], nil
 }
 
 func (r *Repository) featureCommits() ([]*object.Commit, error) {
@@ -142,6 +369,12 @@ func (r *Repository) latestTags() ([]*plumbing.Reference, error) {
 
 	taggedCommits := map[plumbing.Hash][]*plumbing.Reference{}
 	if err = tags.ForEach(func(ref *plumbing.Reference) error {
+		if r.tagPrefix != "" {
+			v, err := version.New(ref.Name().Short())
+			if err != nil || v.Prefix != r.tagPrefix {
+				return nil
+			}
+		}
 		// Both annotated and unannotated tags are supported.
 		commit, err := r.taggedCommit(ref.Hash())
 		if err != nil {
@@ -208,6 +441,8 @@ func (r *Repository) taggedCommit(hash plumbing.Hash) (commit *object.Commit, er
 	return
 }
 
+const defaultCommentChar = "#"
+
 // ErrCommitNotFound indicates missing commits since last tag to generate new
 // tag.
 var ErrCommitNotFound = errors.New("commit not found")
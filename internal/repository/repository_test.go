@@ -0,0 +1,265 @@
+package repository
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+func TestRepository_HooksDir(t *testing.T) {
+	tests := []struct {
+		name       string
+		hooksPath  string
+		global     string
+		want       func(root, home string) string
+		wantShared bool
+	}{
+		{
+			name: "git directory",
+			want: func(root, _ string) string { return filepath.Join(root, ".git", "hooks") },
+		},
+		{
+			name:      "relative core.hooksPath",
+			hooksPath: ".githooks",
+			want:      func(root, _ string) string { return filepath.Join(root, ".githooks") },
+		},
+		{
+			name:      "absolute core.hooksPath",
+			hooksPath: "/etc/git/hooks",
+			want:      func(_, _ string) string { return "/etc/git/hooks" },
+		},
+		{
+			name:       "global core.hooksPath",
+			global:     "/etc/git/hooks",
+			want:       func(_, _ string) string { return "/etc/git/hooks" },
+			wantShared: true,
+		},
+		{
+			name:      "local core.hooksPath overrides global",
+			hooksPath: ".githooks",
+			global:    "/etc/git/hooks",
+			want:      func(root, _ string) string { return filepath.Join(root, ".githooks") },
+		},
+		{
+			name:      "core.hooksPath in home directory",
+			hooksPath: "~/.config/git/hooks",
+			want:      func(_, home string) string { return filepath.Join(home, ".config", "git", "hooks") },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			t.Setenv("HOME", home)
+			t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+			if tt.global != "" {
+				content := "[core]\n\thooksPath = " + tt.global + "\n"
+				if err := os.WriteFile(filepath.Join(home, ".gitconfig"), []byte(content), 0o644); err != nil {
+					t.Fatalf("write global config: %v", err)
+				}
+			}
+
+			root := t.TempDir()
+			repo, err := git.PlainInit(root, false)
+			if err != nil {
+				t.Fatalf("init repository: %v", err)
+			}
+			if tt.hooksPath != "" {
+				cfg, err := repo.Config()
+				if err != nil {
+					t.Fatalf("read config: %v", err)
+				}
+				cfg.Raw.Section("core").SetOption("hooksPath", tt.hooksPath)
+				if err := repo.SetConfig(cfg); err != nil {
+					t.Fatalf("write config: %v", err)
+				}
+			}
+
+			r, err := Open(root)
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+			got, shared, err := r.HooksDir()
+			if err != nil {
+				t.Fatalf("HooksDir() error = %v", err)
+			}
+			if want := tt.want(root, home); got != want {
+				t.Errorf("HooksDir() = %q, want %q", got, want)
+			}
+			if shared != tt.wantShared {
+				t.Errorf("HooksDir() shared = %v, want %v", shared, tt.wantShared)
+			}
+		})
+	}
+}
+
+func TestRepository_Range(t *testing.T) {
+	root := newTestRepository(t)
+	repo, err := git.PlainOpen(root)
+	if err != nil {
+		t.Fatalf("open repository: %v", err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("worktree: %v", err)
+	}
+	commits := 0
+	commit := func(msg string) plumbing.Hash {
+		t.Helper()
+		commits++
+		sig := &object.Signature{
+			Name:  "Jane Doe",
+			Email: "jane@example.com",
+			When:  time.Date(2024, 1, 1, commits, 0, 0, 0, time.UTC),
+		}
+		hash, err := wt.Commit(msg, &git.CommitOptions{AllowEmptyCommits: true, Author: sig, Committer: sig})
+		if err != nil {
+			t.Fatalf("commit: %v", err)
+		}
+		return hash
+	}
+	// History: init - tagged - pushed - local.
+	tagged := commit("feat: tagged")
+	if _, err := repo.CreateTag("v0.1.0", tagged, nil); err != nil {
+		t.Fatalf("tag: %v", err)
+	}
+	pushed := commit("fix: pushed")
+	ref := plumbing.NewHashReference(plumbing.NewRemoteReferenceName("origin", "main"), pushed)
+	if err := repo.Storer.SetReference(ref); err != nil {
+		t.Fatalf("remote reference: %v", err)
+	}
+	local := commit("fix: local")
+
+	tests := []struct {
+		name string
+		rng  string
+		want []string
+	}{
+		{
+			name: "range",
+			rng:  tagged.String() + ".." + local.String(),
+			want: []string{"fix: local", "fix: pushed"},
+		},
+		{
+			name: "revision not on remotes",
+			rng:  local.String(),
+			want: []string{"fix: local"},
+		},
+		{
+			name: "revision on remote",
+			rng:  pushed.String(),
+		},
+		{
+			name: "unknown range start",
+			rng:  "0123456789abcdef0123456789abcdef01234567.." + local.String(),
+			want: []string{"fix: local"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := Open(root)
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+			commits, err := r.Range(tt.rng)
+			if err != nil {
+				t.Fatalf("Range() error = %v", err)
+			}
+			var got []string
+			for _, c := range commits {
+				got = append(got, c.Message)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Range() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRepository_LatestTag(t *testing.T) {
+	root := newTestRepository(t)
+	repo, err := git.PlainOpen(root)
+	if err != nil {
+		t.Fatalf("open repository: %v", err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("worktree: %v", err)
+	}
+	// History: init - v1.0.0 - tools-2.0.0 - local.
+	for i, tag := range []string{"v1.0.0", "tools-2.0.0", ""} {
+		sig := &object.Signature{
+			Name:  "Jane Doe",
+			Email: "jane@example.com",
+			When:  time.Date(2024, 1, 1, i, 0, 0, 0, time.UTC),
+		}
+		hash, err := wt.Commit("fix: "+tag, &git.CommitOptions{AllowEmptyCommits: true, Author: sig, Committer: sig})
+		if err != nil {
+			t.Fatalf("commit: %v", err)
+		}
+		if tag == "" {
+			continue
+		}
+		if _, err := repo.CreateTag(tag, hash, nil); err != nil {
+			t.Fatalf("tag: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name        string
+		prefix      string
+		want        string
+		wantCommits int
+		wantErr     bool
+	}{
+		{
+			name:        "any prefix",
+			want:        "tools-2.0.0",
+			wantCommits: 1,
+		},
+		{
+			name:        "configured prefix",
+			prefix:      "v",
+			want:        "v1.0.0",
+			wantCommits: 2,
+		},
+		{
+			name:    "no tag with prefix",
+			prefix:  "release-",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := Open(root)
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+			r.SetTagPrefix(tt.prefix)
+
+			got, err := r.LatestTag()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LatestTag() error = %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("LatestTag() = %q, want %q", got, tt.want)
+			}
+			if tt.wantErr {
+				return
+			}
+			commits, err := r.FeatureCommits()
+			if err != nil {
+				t.Fatalf("FeatureCommits() error = %v", err)
+			}
+			if len(commits) != tt.wantCommits {
+				t.Errorf("FeatureCommits() = %d commits, want %d", len(commits), tt.wantCommits)
+			}
+		})
+	}
+}
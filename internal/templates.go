@@ -0,0 +1,95 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kam9lo/gover/internal/config"
+	"github.com/kam9lo/gover/internal/repository"
+	"github.com/kam9lo/gover/internal/version"
+)
+
+// messageTemplate is a named template of accepted commit messages.
+type messageTemplate struct {
+	name     string
+	template string
+	version  version.ChangeType
+	parser   *repository.Parser
+	required []string
+	fields   map[string]bool
+}
+
+// newMessageTemplates returns parsers of configured templates in order they
+// are tried.
+func newMessageTemplates(cfg *config.Config) ([]*messageTemplate, error) {
+	required := cfg.RequiredArgs()
+
+	var templates []*messageTemplate
+	for _, pt := range cfg.ParseTemplates() {
+		parser, err := repository.NewParser(pt.Template)
+		if err != nil {
+			return nil, fmt.Errorf("template %s: %w", pt.Name, err)
+		}
+
+		t := &messageTemplate{
+			name:     pt.Name,
+			template: pt.Template,
+			parser:   parser,
+			fields:   map[string]bool{},
+		}
+		t.version.Parse(pt.Version)
+		for _, field := range parser.Fields() {
+			t.fields[field] = true
+		}
+		// Additional templates might not contain every argument, e.g. legacy
+		// format without type, so only arguments used in them are required.
+		for _, r := range required {
+			if t.fields[r] || pt.Name == config.CommitTemplateName {
+				t.required = append(t.required, r)
+			}
+		}
+		templates = append(templates, t)
+	}
+	return templates, nil
+}
+
+// uses returns true if template contains field of given argument.
+func (t *messageTemplate) uses(arg string) bool {
+	return t.fields[arg]
+}
+
+// parse returns message parameters read with the first matching template.
+// When none of the templates matches, missing required parameters of commit
+// template are reported, or [ErrTemplateMismatch] if it has none.
+func (a *App) parse(text string) (repository.Message, *messageTemplate, error) {
+	var commitTemplate *messageTemplate
+	var firstErr error
+	for _, t := range a.templates {
+		if t.name == config.CommitTemplateName {
+			commitTemplate = t
+		}
+		if _, ok := t.parser.Match(text); !ok {
+			continue
+		}
+		msg, err := t.parser.Parse(text, t.required...)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		msg[repository.FieldTemplate] = t.name
+		return msg, t, nil
+	}
+	if firstErr != nil {
+		return nil, nil, firstErr
+	}
+
+	if _, err := commitTemplate.parser.Parse(text, commitTemplate.required...); err != nil {
+		return nil, nil, err
+	}
+	return nil, nil, ErrTemplateMismatch
+}
+
+// ErrTemplateMismatch indicates message matching none of the templates.
+var ErrTemplateMismatch = errors.New("message doesn't match any template")
@@ -0,0 +1,259 @@
+package internal
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/kam9lo/gover/internal/repository"
+	"github.com/kam9lo/gover/internal/version"
+)
+
+const parseTemplatesConfig = `
+templates:
+  commit: "{{.Type}}: {{.Message}}"
+  parse:
+    - name: legacy
+      template: "[{{.Task}}] {{.Message}}"
+      version: minor
+    - name: merge
+      template: "Merge: {{.Branch}}"
+      priority: 1
+    - name: revert
+      template: "Revert: {{.Message}}"
+      priority: -1
+args:
+  - name: Type
+    required: true
+    options:
+      - value: feat
+        version: minor
+      - value: fix
+        version: patch
+  - name: Message
+    required: true
+  - name: Task
+  - name: Branch
+`
+
+func TestNewMessageTemplates(t *testing.T) {
+	app := newTestApp(t, parseTemplatesConfig)
+
+	type template struct {
+		name     string
+		required []string
+		version  version.ChangeType
+	}
+	var got []template
+	for _, tmpl := range app.templates {
+		got = append(got, template{name: tmpl.name, required: tmpl.required, version: tmpl.version})
+	}
+	want := []template{
+		{name: "merge"},
+		{name: "commit", required: []string{"Type", "Message"}},
+		{name: "legacy", required: []string{"Message"}, version: version.ChangeTypeMinor},
+		{name: "revert", required: []string{"Message"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("newMessageTemplates() = %+v, want %+v", got, want)
+	}
+}
+
+func TestApp_parse(t *testing.T) {
+	tests := []struct {
+		name         string
+		text         string
+		wantTemplate string
+		want         repository.Message
+		wantMissing  string
+	}{
+		{
+			name:         "commit template",
+			text:         "feat: add endpoint",
+			wantTemplate: "commit",
+			want:         repository.Message{"Type": "feat", "Message": "add endpoint", "Template": "commit"},
+		},
+		{
+			name:         "higher priority than commit template",
+			text:         "Merge: topic",
+			wantTemplate: "merge",
+			want:         repository.Message{"Branch": "topic", "Template": "merge"},
+		},
+		{
+			name:         "lower priority than commit template",
+			text:         "Revert: add endpoint",
+			wantTemplate: "commit",
+			want:         repository.Message{"Type": "Revert", "Message": "add endpoint", "Template": "commit"},
+		},
+		{
+			name:         "parse template",
+			text:         "[ABC-1] add endpoint",
+			wantTemplate: "legacy",
+			want:         repository.Message{"Task": "ABC-1", "Message": "add endpoint", "Template": "legacy"},
+		},
+		{
+			name:        "fallback to commit template",
+			text:        "add endpoint",
+			wantMissing: "Type",
+		},
+	}
+	app := newTestApp(t, parseTemplatesConfig)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, tmpl, err := app.parse(tt.text)
+			if tt.wantMissing != "" {
+				// Only required fields of commit template are reported.
+				var merr *repository.MissingFieldError
+				if !errors.As(err, &merr) {
+					t.Fatalf("parse() error = %v, want missing field", err)
+				}
+				if merr.Field != tt.wantMissing || merr.Template != app.cfg.Templates.Commit {
+					t.Errorf("parse() missing %s in %q, want %s in commit template", merr.Field, merr.Template, tt.wantMissing)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parse() error = %v", err)
+			}
+			if tmpl.name != tt.wantTemplate {
+				t.Errorf("parse() template = %s, want %s", tmpl.name, tt.wantTemplate)
+			}
+			for field, want := range tt.want {
+				if msg[field] != want {
+					t.Errorf("parse() %s = %q, want %q", field, msg[field], want)
+				}
+			}
+		})
+	}
+
+	t.Run("no match without required args", func(t *testing.T) {
+		app := newTestApp(t, `
+templates:
+  commit: "{{.Type}}: {{.Message}}"
+args:
+  - name: Type
+  - name: Message
+`)
+		if _, _, err := app.parse("random text without colon"); !errors.Is(err, ErrTemplateMismatch) {
+			t.Errorf("parse() error = %v, want %v", err, ErrTemplateMismatch)
+		}
+	})
+}
+
+func TestApp_change(t *testing.T) {
+	tests := []struct {
+		name     string
+		messages []string
+		want     version.ChangeType
+	}{
+		{
+			name:     "option version",
+			messages: []string{"fix: handle EOF"},
+			want:     version.ChangeTypePatch,
+		},
+		{
+			name:     "parse template version",
+			messages: []string{"fix: handle EOF", "[ABC-1] add endpoint"},
+			want:     version.ChangeTypeMinor,
+		},
+		{
+			name:     "parse template without version",
+			messages: []string{"Merge: topic"},
+			want:     version.ChangeTypeNone,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApp(t, parseTemplatesConfig, "feat: init")
+			commitSinceTag(t, app, tt.messages...)
+
+			got, err := app.change()
+			if err != nil {
+				t.Fatalf("change() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("change() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPresets_change(t *testing.T) {
+	tests := []struct {
+		preset  string
+		message string
+		want    version.ChangeType
+	}{
+		{
+			preset:  "conventional",
+			message: "feat!: drop v1 endpoints",
+			want:    version.ChangeTypeMajor,
+		},
+		{
+			preset:  "angular",
+			message: "feat!(api): drop v1 endpoints\n\nBREAKING CHANGE: v1 clients must migrate",
+			want:    version.ChangeTypeMajor,
+		},
+		{
+			preset:  "angular",
+			message: "feat(api): add v2 endpoints",
+			want:    version.ChangeTypeMinor,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.preset, func(t *testing.T) {
+			app := newTestApp(t, "extends: "+tt.preset+"\n", "docs(readme): init")
+			commitSinceTag(t, app, tt.message)
+
+			got, err := app.change()
+			if err != nil {
+				t.Fatalf("change() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("change() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// commitSinceTag tags HEAD commit with v1.0.0 and commits the messages after
+// it.
+func commitSinceTag(t *testing.T, app *App, messages ...string) {
+	t.Helper()
+	root, err := app.repo.Root()
+	if err != nil {
+		t.Fatalf("Root() error = %v", err)
+	}
+	repo, err := git.PlainOpen(root)
+	if err != nil {
+		t.Fatalf("open repository: %v", err)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatalf("head: %v", err)
+	}
+	if _, err := repo.CreateTag("v1.0.0", head.Hash(), nil); err != nil {
+		t.Fatalf("tag: %v", err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("worktree: %v", err)
+	}
+	for i, msg := range messages {
+		_, err := wt.Commit(msg, &git.CommitOptions{
+			AllowEmptyCommits: true,
+			Author: &object.Signature{
+				Name:  "Jane Doe",
+				Email: "jane@example.com",
+				When:  time.Date(2024, 2, 1, i, 0, 0, 0, time.UTC),
+			},
+		})
+		if err != nil {
+			t.Fatalf("commit: %v", err)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package textwrap
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/width"
+)
+
+// Wrap reflows paragraphs of text into lines not wider than width columns.
+// Paragraphs are separated by blank lines. List items start with "-", "*",
+// "+" or a number followed by "." or ")" and are wrapped with continuation
+// lines aligned to item text. Indented lines outside of lists, e.g. code, are
+// kept as they are. Words wider than width are not broken.
+func Wrap(text string, width int) string {
+	var (
+		out    []string
+		words  []string
+		marker string
+	)
+	flush := func() {
+		if len(words) != 0 {
+			out = append(out, fill(words, marker, width)...)
+		}
+		words, marker = nil, ""
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		indented := strings.TrimLeft(line, " \t") != line
+		switch m := listItemRgx.FindString(line); {
+		case line == "":
+			flush()
+			out = append(out, "")
+		case m != "":
+			flush()
+			marker = strings.TrimRight(m, " \t") + " "
+			words = strings.Fields(line[len(m):])
+		case marker != "":
+			// Lines following list item continue it, even if not indented.
+			words = append(words, strings.Fields(line)...)
+		case indented:
+			flush()
+			out = append(out, line)
+		default:
+			words = append(words, strings.Fields(line)...)
+		}
+	}
+	flush()
+
+	return strings.Join(out, "\n")
+}
+
+var listItemRgx = regexp.MustCompile(`^[ \t]*([-*+]|\d+[.)])[ \t]+`)
+
+// fill returns lines of words not wider than width. The first line starts
+// with the marker and next ones are indented by its width.
+func fill(words []string, marker string, width int) []string {
+	indent := strings.Repeat(" ", Width(marker))
+
+	var lines []string
+	line, lineWidth, empty := marker, Width(marker), true
+	for _, word := range words {
+		w := Width(word)
+		if !empty && lineWidth+1+w > width {
+			lines = append(lines, line)
+			line, lineWidth, empty = indent, len(indent), true
+		}
+		if !empty {
+			line += " "
+			lineWidth++
+		}
+		line += word
+		lineWidth += w
+		empty = false
+	}
+	return append(lines, line)
+}
+
+// Width returns number of terminal columns taken by the string. Wide East
+// Asian characters take two columns and combining marks none.
+func Width(s string) int {
+	n := 0
+	for _, r := range s {
+		switch {
+		case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		case isWide(r):
+			n += 2
+		default:
+			n++
+		}
+	}
+	return n
+}
+
+func isWide(r rune) bool {
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return true
+	}
+	return false
+}
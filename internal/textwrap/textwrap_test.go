@@ -0,0 +1,96 @@
+package textwrap
+
+import (
+	"testing"
+)
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		width int
+		want  string
+	}{
+		{
+			name:  "short",
+			text:  "add endpoint",
+			width: 20,
+			want:  "add endpoint",
+		},
+		{
+			name:  "reflowed paragraph",
+			text:  "the quick brown\nfox jumps over the lazy dog",
+			width: 16,
+			want:  "the quick brown\nfox jumps over\nthe lazy dog",
+		},
+		{
+			name:  "paragraphs",
+			text:  "first paragraph of text\n\nsecond one",
+			width: 10,
+			want:  "first\nparagraph\nof text\n\nsecond one",
+		},
+		{
+			name:  "counted in characters",
+			text:  "zażółć gęślą jaźń zażółć gęślą jaźń",
+			width: 17,
+			want:  "zażółć gęślą jaźń\nzażółć gęślą jaźń",
+		},
+		{
+			name:  "wide characters",
+			text:  "日本語 日本語 日本語",
+			width: 13,
+			want:  "日本語 日本語\n日本語",
+		},
+		{
+			name:  "list items",
+			text:  "Changes:\n- first item of the list\n  continued\n* second\n10. third item\nlazily continued",
+			width: 14,
+			want:  "Changes:\n- first item\n  of the list\n  continued\n* second\n10. third item\n    lazily\n    continued",
+		},
+		{
+			name:  "nested list item",
+			text:  "- item\n  - nested item text",
+			width: 12,
+			want:  "- item\n  - nested\n    item\n    text",
+		},
+		{
+			name:  "indented code",
+			text:  "example:\n\n    go run . commit --exec\n",
+			width: 10,
+			want:  "example:\n\n    go run . commit --exec\n",
+		},
+		{
+			name:  "long word",
+			text:  "see https://example.com/a/very/long/url",
+			width: 10,
+			want:  "see\nhttps://example.com/a/very/long/url",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Wrap(tt.text, tt.width); got != tt.want {
+				t.Fatalf("Wrap() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWidth(t *testing.T) {
+	tests := []struct {
+		s    string
+		want int
+	}{
+		{s: "", want: 0},
+		{s: "feat", want: 4},
+		{s: "żółw", want: 4},
+		{s: "żółw", want: 4},
+		{s: "日本", want: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			if got := Width(tt.s); got != tt.want {
+				t.Fatalf("Width() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
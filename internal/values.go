@@ -0,0 +1,153 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kam9lo/gover/internal/config"
+	"github.com/kam9lo/gover/internal/repository"
+)
+
+// ArgValues are sources of arg values of non-interactive commit in order of
+// increasing precedence.
+type ArgValues struct {
+	// JSON is a reader of JSON object with arg names as keys, e.g. stdin.
+	JSON io.Reader
+	// Env are environment variables in "KEY=value" form, only GOVER_ARG_*
+	// ones are read. Arg names are matched case-insensitively.
+	Env []string
+	// Flags are values in "Name=value" form.
+	Flags []string
+}
+
+// CommitNonInteractive renders commit message from values given without
+// prompt, e.g. by scripts and IDE plugins. Values are validated and wrapped
+// like in prompt and message is delivered to the destination.
+func (a *App) CommitNonInteractive(src ArgValues, dst Destination) error {
+	if err := a.checkStaged(dst); err != nil {
+		return err
+	}
+	if err := a.resolveOptions(); err != nil {
+		return err
+	}
+
+	values, err := a.argValues(src)
+	if err != nil {
+		return err
+	}
+
+	var commitTemplate *messageTemplate
+	for _, t := range a.templates {
+		if t.name == config.CommitTemplateName {
+			commitTemplate = t
+		}
+	}
+	var errs []error
+	for _, verr := range a.validate(repository.Message(values), commitTemplate) {
+		errs = append(errs, verr)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("invalid arg values:\n%w", err)
+	}
+	for _, arg := range a.cfg.AllArgs() {
+		values[arg.Name] = arg.Wrap(values[arg.Name])
+	}
+
+	message, err := a.render(values)
+	if err != nil {
+		return err
+	}
+	return a.deliver(message, dst)
+}
+
+// argValues returns values of all args read from sources, args without value
+// are empty.
+func (a *App) argValues(src ArgValues) (map[string]string, error) {
+	values := map[string]string{}
+	for _, arg := range a.cfg.AllArgs() {
+		values[arg.Name] = ""
+	}
+	set := func(name, value string) error {
+		if _, ok := values[name]; !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownArg, name)
+		}
+		values[name] = value
+		return nil
+	}
+
+	if src.JSON != nil {
+		object, err := decodeArgValues(src.JSON)
+		if err != nil {
+			return nil, err
+		}
+		for name, value := range object {
+			if err := set(name, value); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, env := range src.Env {
+		key, value, _ := strings.Cut(env, "=")
+		key, found := strings.CutPrefix(key, config.ArgEnvPrefix)
+		if !found {
+			continue
+		}
+		name := key
+		for _, arg := range a.cfg.AllArgs() {
+			if strings.EqualFold(arg.Name, key) {
+				name = arg.Name
+			}
+		}
+		if err := set(name, value); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, flag := range src.Flags {
+		name, value, ok := strings.Cut(flag, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid arg %q, expected Name=value", flag)
+		}
+		if err := set(name, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
+
+// decodeArgValues decodes JSON object with string, number or boolean values.
+func decodeArgValues(r io.Reader) (map[string]string, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+
+	object := map[string]any{}
+	if err := dec.Decode(&object); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("decode arg values: %w", err)
+	}
+
+	values := make(map[string]string, len(object))
+	for name, value := range object {
+		switch v := value.(type) {
+		case string:
+			values[name] = v
+		case json.Number, bool:
+			values[name] = fmt.Sprint(v)
+		case nil:
+			values[name] = ""
+		default:
+			return nil, fmt.Errorf("decode arg values: %s: expected string, got %T", name, value)
+		}
+	}
+	return values, nil
+}
+
+// ErrUnknownArg indicates value of arg not defined in configuration.
+var ErrUnknownArg = errors.New("unknown arg")
@@ -0,0 +1,72 @@
+package internal
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kam9lo/gover/internal/config"
+)
+
+func TestApp_argValues(t *testing.T) {
+	a := &App{cfg: &config.Config{Args: []config.Arg{
+		{Name: "Type"},
+		{Name: "Scope"},
+		{Name: "Message"},
+		{Name: "Task"},
+	}}}
+
+	tests := []struct {
+		name    string
+		src     ArgValues
+		want    map[string]string
+		wantErr error
+	}{
+		{
+			name: "precedence of sources",
+			src: ArgValues{
+				JSON:  strings.NewReader(`{"Type": "fix", "Message": "from json", "Task": 12}`),
+				Env:   []string{"GOVER_ARG_MESSAGE=from env", "GOVER_ARG_SCOPE=api", "GOVER_TAG_PREFIX=v"},
+				Flags: []string{"Message=from flag"},
+			},
+			want: map[string]string{"Type": "fix", "Scope": "api", "Message": "from flag", "Task": "12"},
+		},
+		{
+			name: "empty stdin",
+			src:  ArgValues{JSON: strings.NewReader(""), Flags: []string{"Type=feat"}},
+			want: map[string]string{"Type": "feat", "Scope": "", "Message": "", "Task": ""},
+		},
+		{
+			name:    "unknown flag arg",
+			src:     ArgValues{Flags: []string{"Tpye=feat"}},
+			wantErr: ErrUnknownArg,
+		},
+		{
+			name:    "unknown env arg",
+			src:     ArgValues{Env: []string{"GOVER_ARG_TICKET=1"}},
+			wantErr: ErrUnknownArg,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := a.argValues(tt.src)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("argValues() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("argValues() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("argValues() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := decodeArgValues(strings.NewReader(`{"Type": ["feat"]}`)); err == nil {
+		t.Errorf("decodeArgValues() expected error for list value")
+	}
+}
@@ -8,7 +8,7 @@ import (
 	"strings"
 )
 
-var semverRegexp = regexp.MustCompile(`^(v)?(\d+)\.(\d+)\.(\d+)(-(\S+)\.(\d))?$`)
+var semverRegexp = regexp.MustCompile(`^(\D*)(\d+)\.(\d+)\.(\d+)(-(\S+)\.(\d))?$`)
 
 // Version wraps semver library with features related with parsed from
 // commit message change type.
@@ -32,7 +32,7 @@ func New(s string) (v *Version, err error) {
 
 	// v1.2.3-alpha.4
 	// matches[0] = "v1.2.3"
-	// matches[1] = 'v'
+	// matches[1] = "v", any non-digit prefix, e.g. "release-"
 	// matches[2] = 1
 	// matches[3] = 2
 	// matches[4] = 3
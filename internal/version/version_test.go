@@ -74,6 +74,17 @@ func TestVersion_Next(t *testing.T) {
 			want:    "v1.2.4",
 			wantErr: false,
 		},
+		{
+			name: "bumps minor with custom prefix",
+			fields: fields{
+				version: "release-1.2.3",
+			},
+			args: args{
+				t: ChangeTypeMinor,
+			},
+			want:    "release-1.3.0",
+			wantErr: false,
+		},
 		{
 			name: "adds pre-release suffix and bumps version",
 			fields: fields{
@@ -4,29 +4,79 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"github.com/kam9lo/gover/internal"
+	"github.com/kam9lo/gover/internal/config"
+	"github.com/kam9lo/gover/internal/prompt"
+	"github.com/kam9lo/gover/internal/repository"
 )
 
 var (
-	FlagConfigFile    = "./gover.yml"
+	FlagConfigFile    = ""
 	FlagCommitMessage = ""
 	FlagPreRelease    = ""
+	FlagFormat        = "text"
+	FlagRange         = ""
+	FlagFromHistory   = false
+	FlagSet           = stringsFlag{}
+	FlagArgs          = stringsFlag{}
+	FlagNoInput       = false
+	FlagPlain         = false
+	FlagExec          = false
+	FlagAmend         = false
+	FlagSignoff       = false
+	FlagSkipCommands  = false
 
 	DefaultRepositoryPath = "."
 )
 
 func init() {
-	flag.StringVar(&FlagConfigFile, "cfg", FlagConfigFile, "configuration file")
+	flag.StringVar(&FlagConfigFile, "cfg", FlagConfigFile, "configuration file, discovered from repository path upwards by default")
 	flag.StringVar(&FlagCommitMessage, "msg-file", FlagCommitMessage, "commit message file path")
 	flag.StringVar(&FlagPreRelease, "pre", FlagPreRelease, "pre-release version")
+	flag.StringVar(&FlagFormat, "format", FlagFormat, "verify report format: text, json, junit")
+	flag.StringVar(&FlagRange, "range", FlagRange, "verify commits of revision range A..B, or of revision not on remotes and tags")
+	flag.Var(&FlagSet, "set", "override configuration value, e.g. --set tag.prefix=v, repeatable")
+	flag.Var(&FlagArgs, "arg", "commit arg value in Name=value form without prompt, repeatable")
+	flag.BoolVar(&FlagNoInput, "no-input", FlagNoInput, "commit without prompt, with args from --arg, GOVER_ARG_* and JSON on stdin")
+	flag.BoolVar(&FlagPlain, "plain", FlagPlain, "line-based prompts, default in dumb terminals and Emacs shells")
+	flag.BoolVar(&FlagExec, "exec", FlagExec, "create commit of staged changes with the message instead of printing it")
+	flag.BoolVar(&FlagAmend, "amend", FlagAmend, "replace HEAD commit, requires --exec")
+	flag.BoolVar(&FlagSkipCommands, "skip-commands", FlagSkipCommands, "don't run options_from commands, e.g. in hooks, their args accept any value")
+	flag.BoolVar(&FlagSignoff, "signoff", FlagSignoff, "add Signed-off-by trailer, requires --exec")
+	flag.BoolVar(&FlagFromHistory, "from-history", FlagFromHistory, "propose init options from existing commits and tags")
 
 	flag.Parse()
 }
 
+// stringsFlag is a repeatable flag collecting all values.
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ", ")
+}
+
+func (f *stringsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// parseCommandArgs parses flags placed after the command, e.g.
+// "gover verify --format=json .", and returns positional arguments only.
+func parseCommandArgs(args []string) (positional []string) {
+	for len(args) > 0 {
+		positional = append(positional, args[0])
+		_ = flag.CommandLine.Parse(args[1:])
+		args = flag.Args()
+	}
+	return
+}
+
 func main() {
-	args := flag.Args()
+	args := parseCommandArgs(flag.Args())
 	if len(args) < 1 || args[0] == "" {
 		exit(errors.New("missing command"))
 	}
@@ -36,7 +86,26 @@ func main() {
 		repositoryPath = args[1]
 	}
 
-	app, err := internal.NewApp(FlagConfigFile, repositoryPath)
+	if plainPrompts() {
+		prompt.SetDriver(prompt.NewLines(os.Stdin, os.Stderr))
+	}
+
+	if args[0] == "config" {
+		exit(configCommand(args[1:]))
+	}
+	if args[0] == "hooks" {
+		exit(hooksCommand(args[1:]))
+	}
+	if args[0] == "init" {
+		exit(internal.Init(repositoryPath, FlagFromHistory))
+	}
+
+	app, err := internal.NewApp(config.Options{
+		Path:         FlagConfigFile,
+		Env:          os.Environ(),
+		Overrides:    FlagSet,
+		SkipCommands: FlagSkipCommands,
+	}, repositoryPath)
 	if err != nil {
 		exit(err)
 	}
@@ -51,9 +120,29 @@ func main() {
 	case "next":
 		err = app.Next(FlagPreRelease)
 	case "commit":
-		err = app.Commit(FlagCommitMessage)
+		var dst internal.Destination
+		dst, err = commitDestination()
+		if err != nil {
+			break
+		}
+		if nonInteractive() {
+			err = app.CommitNonInteractive(internal.ArgValues{
+				JSON:  pipedStdin(),
+				Env:   os.Environ(),
+				Flags: FlagArgs,
+			}, dst)
+		} else {
+			err = app.Commit(dst)
+		}
 	case "verify":
-		err = app.Verify()
+		switch {
+		case FlagCommitMessage != "":
+			err = app.VerifyMessage(FlagCommitMessage, FlagFormat)
+		case FlagRange != "":
+			err = app.VerifyRange(FlagRange, FlagFormat)
+		default:
+			err = app.Verify(FlagFormat)
+		}
 	case "change":
 		err = app.Change()
 	case "commits":
@@ -66,13 +155,121 @@ func main() {
 	exit(err)
 }
 
+// commitDestination returns destination of commit message from flags.
+func commitDestination() (internal.Destination, error) {
+	if !FlagExec && (FlagAmend || FlagSignoff) {
+		return internal.Destination{}, errors.New("--amend and --signoff require --exec")
+	}
+	if FlagExec && FlagCommitMessage != "" {
+		return internal.Destination{}, errors.New("--exec can't be used with --msg-file")
+	}
+	return internal.Destination{
+		MsgFile: FlagCommitMessage,
+		Exec:    FlagExec,
+		Commit: repository.CommitOptions{
+			Amend:   FlagAmend,
+			Signoff: FlagSignoff,
+		},
+	}, nil
+}
+
+// nonInteractive returns true if commit arg values are given without prompt.
+// Environment values are read only with flags, so variables left in the shell
+// don't skip the prompt, e.g. in prepare-commit-msg hook.
+func nonInteractive() bool {
+	return FlagNoInput || len(FlagArgs) > 0
+}
+
+// plainPrompts returns true if prompts should be line-based, as terminal
+// can't render interactive ones.
+func plainPrompts() bool {
+	return FlagPlain || os.Getenv("TERM") == "dumb" || os.Getenv("INSIDE_EMACS") != ""
+}
+
+// pipedStdin returns stdin when --no-input is set and stdin is not a
+// terminal, e.g. JSON piped into gover, or nil otherwise. Stdin is not read
+// by default, as it may stay open without data, e.g. in CI.
+func pipedStdin() io.Reader {
+	if !FlagNoInput {
+		return nil
+	}
+	info, err := os.Stdin.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice != 0 {
+		return nil
+	}
+	return os.Stdin
+}
+
+// configCommand runs "gover config [COMMAND] [PATH]" commands, which don't
+// require valid configuration.
+func configCommand(args []string) error {
+	if len(args) < 1 || args[0] == "" {
+		return errors.New("missing config command")
+	}
+
+	repositoryPath := DefaultRepositoryPath
+	if len(args) > 1 {
+		repositoryPath = args[1]
+	}
+
+	switch args[0] {
+	case "validate":
+		return internal.ValidateConfig(FlagConfigFile, repositoryPath)
+	case "path":
+		return internal.ConfigPath(FlagConfigFile, repositoryPath)
+	case "schema":
+		return internal.ConfigSchema()
+	default:
+		return errors.New("invalid config command")
+	}
+}
+
+// hooksCommand runs "gover hooks [COMMAND] [PATH]" commands, which don't
+// require valid configuration.
+func hooksCommand(args []string) error {
+	if len(args) < 1 || args[0] == "" {
+		return errors.New("missing hooks command")
+	}
+
+	repositoryPath := DefaultRepositoryPath
+	if len(args) > 1 {
+		repositoryPath = args[1]
+	}
+
+	switch args[0] {
+	case "install":
+		return internal.InstallHooks(repositoryPath)
+	case "uninstall":
+		return internal.UninstallHooks(repositoryPath)
+	case "status":
+		return internal.HooksStatus(repositoryPath)
+	default:
+		return errors.New("invalid hooks command")
+	}
+}
+
+// Exit codes of the application.
+const (
+	ExitCodeOK                 = 0
+	ExitCodeError              = 1
+	ExitCodeVerificationFailed = 2
+)
+
 func exit(err error) {
+	if errors.Is(err, internal.ErrVerificationFailed) {
+		// Violations are already printed in verification report.
+		os.Exit(ExitCodeVerificationFailed)
+	}
+	if errors.Is(err, internal.ErrAborted) {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(ExitCodeError)
+	}
 	if err != nil {
-		fmt.Println(err.Error())
-		fmt.Print(usage)
-		os.Exit(1)
+		fmt.Fprintln(os.Stderr, err.Error())
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(ExitCodeError)
 	}
-	os.Exit(0)
+	os.Exit(ExitCodeOK)
 }
 
 const usage = `
@@ -81,20 +278,62 @@ Usage:
 gover [COMMAND] [PATH] [VERSION]
 
 Commands:
+	init	Generate configuration file from preset with prompts,
+			with --from-history proposes types and scopes used in
+			existing commits
 	version Print version
 	next 	Print next version based on feature branch commit log.
 	latest  Print latest version tag
 	commit	Print prompt and generate commit message from template
-			and provided values
-	verify	Verify commit messages since last
+			and provided values, preview it with lint and verify
+			findings and print confirmed message to stdout or
+			--msg-file, with --exec creates the commit of staged
+			changes, optionally with --amend and --signoff, with
+			--arg or --no-input values are taken without prompt,
+			also from GOVER_ARG_* variables, --no-input also
+			reads JSON from stdin
+	verify	Verify commit messages since last tag and print report,
+			use --format=json or --format=junit for CI, with --msg-file
+			verifies only the message from file, with --range commits
+			of revision range, e.g. pushed ones, --skip-commands
+			doesn't run options_from commands
 	change	Print type of most important change made since last version
 	tag		Tag commit with version based on commits since previous tag
+	config validate
+			Check configuration file and print problems with their
+			location, e.g. template fields without args
+	config path
+			Print path of configuration file, fails when not found
+	config schema
+			Print JSON Schema of configuration file
+	hooks install
+			Install prepare-commit-msg, commit-msg and pre-push hooks
+			into core.hooksPath or .git/hooks, existing hooks are
+			renamed with .local suffix and chained
+	hooks uninstall
+			Remove hooks installed by gover and restore chained ones
+	hooks status
+			Print hooks directory and state of hooks
 
 Examples:
 
+Generate configuration file:
+$ gover init --from-history .
+
 Show commit message prompt (ctrl-c to skip):
 $ gover commit .
 
+Show line-based prompt, e.g. in Emacs shell:
+$ gover commit --plain .
+
+Commit with message from prompt:
+$ gover commit . | git commit -F -
+$ gover commit --exec --signoff .
+
+Generate commit message without prompt:
+$ gover commit --arg Type=feat --arg Message="add parser" .
+$ echo '{"Type": "fix", "Message": "handle EOF"}' | gover commit --no-input .
+
 Show next version:
 $ gover next .
 v0.1.0
@@ -105,4 +344,23 @@ minor
 
 Create next version tag:
 $ gover tag .
+
+Install git hooks prompting for and verifying commit messages:
+$ gover hooks install .
+
+Verify commit message file in commit-msg hook:
+$ gover verify --msg-file .git/COMMIT_EDITMSG .
+
+Override configuration values:
+$ GOVER_TAG_PREFIX=release- gover --set tag.pre=rc next .
+release-1.3.0-rc.1
+
+Check configuration file:
+$ gover config validate .
+gover.yml:2:25: error: field Mesage is not defined in args, did you mean Message?
+
+Exit codes:
+	0	Success
+	1	Error
+	2	Verification failed or invalid configuration
 `